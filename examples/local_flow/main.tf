@@ -2,9 +2,13 @@ provider "nifi" {
   host = "${var.nifi_host}"
 }
 
+data "nifi_process_group" "root" {
+  path = "root"
+}
+
 resource "nifi_process_group" "local_flow" {
   component {
-    parent_group_id = "${var.nifi_root_process_group_id}"
+    parent_group_id = "${data.nifi_process_group.root.id}"
     name = "local_flow"
 
     position {
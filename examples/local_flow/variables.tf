@@ -1,7 +1,3 @@
 variable "nifi_host" {
   description = "NiFi instance where the flow should be created"
 }
-
-variable "nifi_root_process_group_id" {
-  description = "ID of process group where flow resources should be created"
-}
@@ -0,0 +1,96 @@
+package nifi
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Bulletin section
+
+const (
+	BulletinLevel_ERROR   = "ERROR"
+	BulletinLevel_WARNING = "WARNING"
+	BulletinLevel_INFO    = "INFO"
+	BulletinLevel_DEBUG   = "DEBUG"
+)
+
+type BulletinComponent struct {
+	Id          int64  `json:"id"`
+	NodeAddress string `json:"nodeAddress,omitempty"`
+	Category    string `json:"category"`
+	GroupId     string `json:"groupId"`
+	SourceId    string `json:"sourceId"`
+	SourceName  string `json:"sourceName"`
+	Level       string `json:"level"`
+	Message     string `json:"message"`
+	Timestamp   string `json:"timestamp"`
+}
+
+type Bulletin struct {
+	Id       int64  `json:"id"`
+	GroupId  string `json:"groupId"`
+	SourceId string `json:"sourceId"`
+	CanRead  bool   `json:"canRead"`
+	// Details are left out when the bulletin source cannot be read
+	Bulletin *BulletinComponent `json:"bulletin,omitempty"`
+}
+
+type BulletinBoard struct {
+	BulletinBoard struct {
+		Bulletins []Bulletin `json:"bulletins"`
+		Generated string     `json:"generated"`
+	} `json:"bulletinBoard"`
+}
+
+// BulletinFilter narrows the bulletin board, GroupId, SourceId and SourceName are regular expressions.
+// Only bulletins with an id greater than After are returned, Limit is ignored when zero.
+type BulletinFilter struct {
+	GroupId    string
+	SourceId   string
+	SourceName string
+	After      int64
+	Limit      int
+}
+
+func (c *Client) GetBulletinBoard(filter BulletinFilter) (*BulletinBoard, error) {
+	query := url.Values{}
+	if filter.GroupId != "" {
+		query.Set("groupId", filter.GroupId)
+	}
+	if filter.SourceId != "" {
+		query.Set("sourceId", filter.SourceId)
+	}
+	if filter.SourceName != "" {
+		query.Set("sourceName", filter.SourceName)
+	}
+	if filter.After > 0 {
+		query.Set("after", strconv.FormatInt(filter.After, 10))
+	}
+	if filter.Limit > 0 {
+		query.Set("limit", strconv.Itoa(filter.Limit))
+	}
+	requestUrl := fmt.Sprintf("%s/flow/bulletin-board?%s",
+		baseurl(c.Config), query.Encode())
+	board := BulletinBoard{}
+	_, err := c.JsonCall("GET", requestUrl, nil, &board)
+	if nil != err {
+		return nil, err
+	}
+	return &board, nil
+}
+
+// LastBulletinId returns the id of the most recent bulletin matching the filter, zero when there is none.
+func (c *Client) LastBulletinId(filter BulletinFilter) (int64, error) {
+	board, err := c.GetBulletinBoard(filter)
+	if nil != err {
+		return 0, err
+	}
+	last := filter.After
+	for _, bulletin := range board.BulletinBoard.Bulletins {
+		if bulletin.Id > last {
+			last = bulletin.Id
+		}
+	}
+	return last, nil
+}
@@ -9,7 +9,7 @@ import (
 	"io"
 	"log"
 	"net/http"
-	"sync"
+	"regexp"
 	"time"
 )
 
@@ -17,12 +17,17 @@ type Client struct {
 	Config Config
 	Client *http.Client
 	auth   *authentication
-	// The mutex is used by the plugin to prevent parallel execution of some update/delete operations.
+	// The locks are used by the plugin to prevent parallel execution of some update/delete operations.
 	// There are scenarios when updating a connection involves modifying related processors and vice versa.
 	// This breaks Terraform model to some extent but at the same time is unavoidable in NiFi world.
-	// Currently only flows that involve cross-resource interactions are wrapped into lock/unlock sections.
-	// Most of operations can still be performed in parallel.
-	Lock sync.Mutex
+	// Operations lock the entities they affect (see LockKeys), so that only the ones sharing a component,
+	// a connection endpoint or a parent group are serialized. Changes to different process groups proceed in parallel.
+	// The locks are shared by the copies returned by TrackPermissions.
+	locks *keyedLocks
+	// Ports waiting for a connection to be started, shared by the copies returned by TrackPermissions.
+	portStarts *portStarts
+	// Permission denied errors are recorded here when tracking is enabled.
+	denied *permissionDenials
 }
 
 type authentication struct {
@@ -101,9 +106,11 @@ func NewClient(conf Config) (*Client, error) {
 	}
 
 	client := &Client{
-		Client: httpClient,
-		Config: conf,
-		auth:   auth,
+		Client:     httpClient,
+		Config:     conf,
+		auth:       auth,
+		locks:      newKeyedLocks(),
+		portStarts: newPortStarts(),
 	}
 
 	return client, nil
@@ -144,9 +151,16 @@ func (c *Client) WaitUtil(max_wait time.Duration, statusCheck StatusCheckFn) err
 	return nil
 }
 
+// Password values, such as the proxy password of remote process groups, are not written to the logs.
+var requestPasswordPattern = regexp.MustCompile(`("[A-Za-z ]*[Pp]assword"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+func redactRequestBody(body []byte) string {
+	return requestPasswordPattern.ReplaceAllString(string(body), `$1"********"`)
+}
+
 func (c *Client) JsonCall(method string, url string, bodyIn interface{}, bodyOut interface{}) (int, error) {
 	b, _ := json.Marshal(bodyIn)
-	log.Printf("[DEBUG]: request data %s", string(b))
+	log.Printf("[DEBUG]: request data %s", redactRequestBody(b))
 
 	var requestBody io.Reader = nil
 	if bodyIn != nil {
@@ -182,6 +196,9 @@ func (c *Client) JsonCall(method string, url string, bodyIn interface{}, bodyOut
 		if err != nil {
 			return 0, err
 		}
+		if response.StatusCode == http.StatusForbidden {
+			return response.StatusCode, c.permissionDenied(method, url, string(bodyBytes))
+		}
 		return response.StatusCode, fmt.Errorf("the call has failed with the code of %d , the result is %s", response.StatusCode, string(bodyBytes))
 	}
 
@@ -375,57 +392,15 @@ func (c *Client) DeleteGroup(group *Group) error {
 	return err
 }
 
-//remote process group
-type RemoteProcessGroupComponent struct {
-	Id                string   `json:"id,omitempty"`
-	ParentGroupId     string   `json:"parentGroupId"`
-	Name              string   `json:"name"`
-	Position          Position `json:"position"`
-	TargetUris        string   `json:"targetUris"`
-	TransportProtocol string   `json:"transportProtocol"`
-}
-
-type RemoteProcessGroup struct {
-	Revision  Revision                    `json:"revision"`
-	Component RemoteProcessGroupComponent `json:"component"`
-}
-
-func (c *Client) CreateRemoteProcessGroup(processGroup *RemoteProcessGroup) error {
-	url := fmt.Sprintf("%s/process-groups/%s/remote-process-groups",
-		baseurl(c.Config), processGroup.Component.ParentGroupId)
-	_, err := c.JsonCall("POST", url, processGroup, processGroup)
-	return err
-}
-
-func (c *Client) GetRemoteProcessGroup(processGroupId string) (*RemoteProcessGroup, error) {
-	url := fmt.Sprintf("%s/remote-process-groups/%s",
-		baseurl(c.Config), processGroupId)
-	processGroup := RemoteProcessGroup{}
-	code, err := c.JsonCall("GET", url, nil, &processGroup)
-	if code == 404 {
-		return nil, fmt.Errorf("not_found")
-	}
-	if nil != err {
-		return nil, err
-	}
-	return &processGroup, nil
-}
+// ReportingTask section
 
-func (c *Client) UpdateRemoteProcessGroup(processGroup *RemoteProcessGroup) error {
-	url := fmt.Sprintf("%s/remote-process-groups/%s",
-		baseurl(c.Config), processGroup.Component.Id)
-	_, err := c.JsonCall("PUT", url, processGroup, processGroup)
-	return err
-}
+type ReportingTaskState string
 
-func (c *Client) DeleteRemoteProcessGroup(processGroup *RemoteProcessGroup) error {
-	url := fmt.Sprintf("%s/process-groups/%s?version=%d",
-		baseurl(c.Config), processGroup.Component.Id, processGroup.Revision.Version)
-	_, err := c.JsonCall("DELETE", url, nil, nil)
-	return err
-}
-
-// ReportingTask section
+const (
+	ReportingTaskState_RUNNING  ReportingTaskState = "RUNNING"
+	ReportingTaskState_STOPPED  ReportingTaskState = "STOPPED"
+	ReportingTaskState_DISABLED ReportingTaskState = "DISABLED"
+)
 
 type ReportingTaskComponent struct {
 	Id                 string                 `json:"id,omitempty"`
@@ -436,6 +411,11 @@ type ReportingTaskComponent struct {
 	SchedulingStrategy string                 `json:"schedulingStrategy"`
 	SchedulingPeriod   string                 `json:"schedulingPeriod"`
 	Properties         map[string]interface{} `json:"properties"`
+	State              ReportingTaskState     `json:"state,omitempty"`
+	ActiveThreadCount  int                    `json:"activeThreadCount,omitempty"`
+
+	ValidationStatus ValidationStatus `json:"validationStatus,omitempty"`
+	ValidationErrors []string         `json:"validationErrors,omitempty"`
 }
 
 type ReportingTask struct {
@@ -443,6 +423,11 @@ type ReportingTask struct {
 	Component ReportingTaskComponent `json:"component"`
 }
 
+type ReportingTaskRunStatus struct {
+	Revision Revision           `json:"revision"`
+	State    ReportingTaskState `json:"state"`
+}
+
 func (c *Client) CreateReportingTask(reportingTask *ReportingTask) error {
 	url := fmt.Sprintf("%s/controller/reporting-tasks",
 		baseurl(c.Config))
@@ -486,3 +471,53 @@ func (c *Client) DeleteReportingTask(reportingTask *ReportingTask) error {
 	_, err := c.JsonCall("DELETE", url, nil, nil)
 	return err
 }
+
+func (c *Client) setReportingTaskRunStatus(reportingTask *ReportingTask, state ReportingTaskState) error {
+	url := fmt.Sprintf("%s/reporting-tasks/%s/run-status",
+		baseurl(c.Config), reportingTask.Component.Id)
+	runStatus := ReportingTaskRunStatus{
+		Revision: Revision{
+			Version: reportingTask.Revision.Version,
+		},
+		State: state,
+	}
+	_, err := c.JsonCall("PUT", url, runStatus, reportingTask)
+	if nil != err {
+		return err
+	}
+
+	// A stopped task may still be running its last execution
+	reportingTaskId := reportingTask.Component.Id
+	return c.WaitUtil(120*time.Second, func(c *Client) bool {
+		current, err := c.GetReportingTask(reportingTaskId)
+		if err != nil {
+			return false
+		}
+		*reportingTask = *current
+		return current.Component.State == state && (state == ReportingTaskState_RUNNING || current.Component.ActiveThreadCount == 0)
+	})
+}
+
+// SetReportingTaskState moves the task to the given state, disabled tasks are stopped before they are started
+// and running tasks are stopped before they are disabled.
+func (c *Client) SetReportingTaskState(reportingTask *ReportingTask, state ReportingTaskState) error {
+	current := reportingTask.Component.State
+	if current == state {
+		return nil
+	}
+	if current != ReportingTaskState_STOPPED && state != ReportingTaskState_STOPPED {
+		err := c.setReportingTaskRunStatus(reportingTask, ReportingTaskState_STOPPED)
+		if nil != err {
+			return err
+		}
+	}
+	return c.setReportingTaskRunStatus(reportingTask, state)
+}
+
+func (c *Client) StartReportingTask(reportingTask *ReportingTask) error {
+	return c.SetReportingTaskState(reportingTask, ReportingTaskState_RUNNING)
+}
+
+func (c *Client) StopReportingTask(reportingTask *ReportingTask) error {
+	return c.SetReportingTaskState(reportingTask, ReportingTaskState_STOPPED)
+}
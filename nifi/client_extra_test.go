@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -238,4 +239,135 @@ func TestClientRemoteProcessGroupCreate(t *testing.T) {
 	processGroup.Component.Name = "test_remote_pg2"
 	err = client.UpdateRemoteProcessGroup(&processGroup)
 	assert.Equal(t, err, nil)
+
+	err = client.StartRemoteProcessGroupTransmission(&processGroup)
+	assert.Equal(t, err, nil)
+
+	err = client.StopRemoteProcessGroupTransmission(&processGroup)
+	assert.Equal(t, err, nil)
+
+	err = client.DeleteRemoteProcessGroup(&processGroup)
+	assert.Equal(t, err, nil)
+}
+
+func TestClientCurrentUser(t *testing.T) {
+	client := setup()
+	user, err := client.GetCurrentUser()
+	assert.Nil(t, err)
+	assert.NotEmpty(t, user.Identity)
+}
+
+func TestClientControllerConfig(t *testing.T) {
+	client := setup()
+	config, err := client.GetControllerConfig()
+	assert.Nil(t, err)
+	assert.NotZero(t, config.Component.MaxTimerDrivenThreadCount)
+
+	version := config.Revision.Version
+	err = client.UpdateControllerConfig(config)
+	assert.Nil(t, err)
+	assert.Equal(t, version+1, config.Revision.Version)
+}
+
+func TestClientProvenance(t *testing.T) {
+	client := setup()
+	provenance, err := client.QueryProvenance(ProvenanceRequest{
+		MaxResults: 10,
+		StartDate:  time.Now().Add(-time.Hour).UTC().Format(ProvenanceDateFormat),
+	}, time.Minute)
+	assert.Nil(t, err)
+	assert.True(t, provenance.Provenance.Finished)
+	assert.LessOrEqual(t, len(provenance.Provenance.Results.ProvenanceEvents), 10)
+}
+
+func TestClientBulletinBoard(t *testing.T) {
+	client := setup()
+	board, err := client.GetBulletinBoard(BulletinFilter{Limit: 5})
+	assert.Nil(t, err)
+	assert.NotEmpty(t, board.BulletinBoard.Generated)
+	assert.LessOrEqual(t, len(board.BulletinBoard.Bulletins), 5)
+
+	last, err := client.LastBulletinId(BulletinFilter{})
+	assert.Nil(t, err)
+	board, err = client.GetBulletinBoard(BulletinFilter{After: last})
+	assert.Nil(t, err)
+	for _, bulletin := range board.BulletinBoard.Bulletins {
+		assert.Greater(t, bulletin.Id, last)
+	}
+}
+
+// clusterSetup returns the cluster of the test server, the test is skipped when the server is not clustered.
+func clusterSetup(t *testing.T) (*Client, *Cluster) {
+	client := setup()
+	cluster, err := client.GetCluster()
+	if err != nil {
+		t.Skipf("test server is not clustered: %s", err)
+	}
+	return client, cluster
+}
+
+func TestClientCluster(t *testing.T) {
+	client, cluster := clusterSetup(t)
+	assert.NotEmpty(t, cluster.Cluster.Nodes)
+
+	coordinators := 0
+	for _, node := range cluster.Cluster.Nodes {
+		assert.NotEmpty(t, node.NodeId)
+		assert.NotEmpty(t, node.Address)
+		assert.NotEmpty(t, node.Status)
+		if node.HasRole(ClusterNodeRole_COORDINATOR) {
+			coordinators++
+		}
+	}
+	assert.Equal(t, 1, coordinators)
+
+	first := cluster.Cluster.Nodes[0]
+	node, err := client.FindClusterNode(first.Address, first.ApiPort)
+	assert.Nil(t, err)
+	assert.Equal(t, first.NodeId, node.NodeId)
+
+	current, err := client.GetClusterNode(first.NodeId)
+	assert.Nil(t, err)
+	assert.Equal(t, first.Address, current.Node.Address)
+
+	_, err = client.GetClusterNode("00000000-0000-0000-0000-000000000000")
+	assert.NotNil(t, err)
+}
+
+func TestClientClusterNodeStatus(t *testing.T) {
+	client, cluster := clusterSetup(t)
+
+	// Only a node which neither serves the test requests nor holds a cluster role is disconnected
+	var node *ClusterNodeComponent
+	for i, v := range cluster.Cluster.Nodes {
+		if v.Status == ClusterNodeStatus_CONNECTED && len(v.Roles) == 0 &&
+			fmt.Sprintf("%s:%d", v.Address, v.ApiPort) != client.Config.Host {
+			node = &cluster.Cluster.Nodes[i]
+			break
+		}
+	}
+	if node == nil {
+		t.Skip("the cluster has no connected node without roles")
+	}
+
+	err := client.SetClusterNodeStatus(node.NodeId, ClusterNodeStatus_CONNECTING, time.Minute)
+	assert.NotNil(t, err)
+
+	err = client.SetClusterNodeStatus(node.NodeId, ClusterNodeStatus_DISCONNECTED, 2*time.Minute)
+	assert.Nil(t, err)
+	err = client.WaitClusterNodeStatus(node.NodeId, ClusterNodeStatus_DISCONNECTED, time.Minute)
+	assert.Nil(t, err)
+
+	// Already in the desired status
+	err = client.SetClusterNodeStatus(node.NodeId, ClusterNodeStatus_DISCONNECTED, time.Minute)
+	assert.Nil(t, err)
+
+	err = client.SetClusterNodeStatus(node.NodeId, ClusterNodeStatus_CONNECTED, 5*time.Minute)
+	assert.Nil(t, err)
+	current, err := client.GetClusterNode(node.NodeId)
+	assert.Nil(t, err)
+	assert.Equal(t, ClusterNodeStatus_CONNECTED, current.Node.Status)
+
+	err = client.WaitClusterNodeStatus(node.NodeId, ClusterNodeStatus_OFFLOADED, 5*time.Second)
+	assert.NotNil(t, err)
 }
@@ -1,6 +1,7 @@
 package nifi
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -74,9 +75,30 @@ func TestClientReportingTaskCreate(t *testing.T) {
 	assert.Nil(t, err)
 	assert.NotEmpty(t, reportingTask.Component.Id)
 
+	err = client.StartReportingTask(&reportingTask)
+	assert.Nil(t, err)
+	assert.Equal(t, ReportingTaskState_RUNNING, reportingTask.Component.State)
+
+	err = client.SetReportingTaskState(&reportingTask, ReportingTaskState_DISABLED)
+	assert.Nil(t, err)
+	assert.Equal(t, ReportingTaskState_DISABLED, reportingTask.Component.State)
+
 	err = client.DeleteReportingTask(&reportingTask)
 	assert.Nil(t, err)
 
 	client.DeleteProcessGroup(&processGroup)
 	assert.Nil(t, err)
 }
+
+func TestClientRedactRequestBody(t *testing.T) {
+	processGroup := RemoteProcessGroup{}
+	processGroup.Component.ProxyUser = "proxy"
+	processGroup.Component.ProxyPassword = `se"cret`
+	body, err := json.Marshal(processGroup)
+	assert.Nil(t, err)
+
+	redacted := redactRequestBody(body)
+	assert.NotContains(t, redacted, "cret")
+	assert.Contains(t, redacted, `"proxyPassword":"********"`)
+	assert.Contains(t, redacted, `"proxyUser":"proxy"`)
+}
@@ -0,0 +1,183 @@
+package nifi
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+// Cluster section
+
+type ClusterNodeStatus string
+
+const (
+	ClusterNodeStatus_CONNECTING    ClusterNodeStatus = "CONNECTING"
+	ClusterNodeStatus_CONNECTED     ClusterNodeStatus = "CONNECTED"
+	ClusterNodeStatus_DISCONNECTING ClusterNodeStatus = "DISCONNECTING"
+	ClusterNodeStatus_DISCONNECTED  ClusterNodeStatus = "DISCONNECTED"
+	ClusterNodeStatus_OFFLOADING    ClusterNodeStatus = "OFFLOADING"
+	ClusterNodeStatus_OFFLOADED     ClusterNodeStatus = "OFFLOADED"
+)
+
+const (
+	ClusterNodeRole_PRIMARY     = "Primary Node"
+	ClusterNodeRole_COORDINATOR = "Cluster Coordinator"
+)
+
+type ClusterNodeComponent struct {
+	NodeId            string            `json:"nodeId"`
+	Address           string            `json:"address,omitempty"`
+	ApiPort           int               `json:"apiPort,omitempty"`
+	Status            ClusterNodeStatus `json:"status"`
+	Heartbeat         string            `json:"heartbeat,omitempty"`
+	Roles             []string          `json:"roles,omitempty"`
+	ActiveThreadCount int               `json:"activeThreadCount,omitempty"`
+	Queued            string            `json:"queued,omitempty"`
+	NodeStartTime     string            `json:"nodeStartTime,omitempty"`
+}
+
+func (node *ClusterNodeComponent) HasRole(role string) bool {
+	for _, v := range node.Roles {
+		if v == role {
+			return true
+		}
+	}
+	return false
+}
+
+type ClusterNode struct {
+	Node ClusterNodeComponent `json:"node"`
+}
+
+type Cluster struct {
+	Cluster struct {
+		Nodes     []ClusterNodeComponent `json:"nodes"`
+		Generated string                 `json:"generated"`
+	} `json:"cluster"`
+}
+
+func (c *Client) GetCluster() (*Cluster, error) {
+	url := fmt.Sprintf("%s/controller/cluster",
+		baseurl(c.Config))
+	cluster := Cluster{}
+	_, err := c.JsonCall("GET", url, nil, &cluster)
+	if nil != err {
+		return nil, err
+	}
+	return &cluster, nil
+}
+
+func (c *Client) GetClusterNode(nodeId string) (*ClusterNode, error) {
+	url := fmt.Sprintf("%s/controller/cluster/nodes/%s",
+		baseurl(c.Config), nodeId)
+	node := ClusterNode{}
+	code, err := c.JsonCall("GET", url, nil, &node)
+	if code == 404 {
+		return nil, fmt.Errorf("not_found")
+	}
+	if nil != err {
+		return nil, err
+	}
+	return &node, nil
+}
+
+// FindClusterNode looks up a node by its address and, when not zero, its API port.
+func (c *Client) FindClusterNode(address string, apiPort int) (*ClusterNodeComponent, error) {
+	cluster, err := c.GetCluster()
+	if nil != err {
+		return nil, err
+	}
+	for i, node := range cluster.Cluster.Nodes {
+		if node.Address == address && (apiPort == 0 || node.ApiPort == apiPort) {
+			return &cluster.Cluster.Nodes[i], nil
+		}
+	}
+	return nil, fmt.Errorf("not_found")
+}
+
+func (c *Client) UpdateClusterNodeStatus(nodeId string, status ClusterNodeStatus) error {
+	url := fmt.Sprintf("%s/controller/cluster/nodes/%s",
+		baseurl(c.Config), nodeId)
+	node := ClusterNode{
+		Node: ClusterNodeComponent{
+			NodeId: nodeId,
+			Status: status,
+		},
+	}
+	_, err := c.JsonCall("PUT", url, node, nil)
+	return err
+}
+
+func (c *Client) DeleteClusterNode(nodeId string) error {
+	url := fmt.Sprintf("%s/controller/cluster/nodes/%s",
+		baseurl(c.Config), nodeId)
+	_, err := c.JsonCall("DELETE", url, nil, nil)
+	return err
+}
+
+// WaitClusterNodeStatus waits until the node reports the given status.
+func (c *Client) WaitClusterNodeStatus(nodeId string, status ClusterNodeStatus, max_wait time.Duration) error {
+	current := ClusterNodeStatus("")
+	err := c.WaitUtil(max_wait, func(c *Client) bool {
+		node, err := c.GetClusterNode(nodeId)
+		if err != nil {
+			return false
+		}
+		current = node.Node.Status
+		log.Printf("[INFO] Waiting for cluster node %s to be %s, currently %s", nodeId, status, current)
+		return current == status
+	})
+	if err != nil {
+		return fmt.Errorf("cluster node %s is still %s after %s, expected %s", nodeId, current, max_wait, status)
+	}
+	return nil
+}
+
+// SetClusterNodeStatus drives a node to CONNECTED, DISCONNECTED or OFFLOADED. A connected node is disconnected
+// before it is offloaded, an offloaded node is connected again before it is disconnected.
+func (c *Client) SetClusterNodeStatus(nodeId string, status ClusterNodeStatus, max_wait time.Duration) error {
+	node, err := c.GetClusterNode(nodeId)
+	if nil != err {
+		return err
+	}
+	current := node.Node.Status
+	if current == status {
+		return nil
+	}
+
+	steps := []ClusterNodeStatus{}
+	switch status {
+	case ClusterNodeStatus_CONNECTED:
+		steps = append(steps, ClusterNodeStatus_CONNECTED)
+	case ClusterNodeStatus_DISCONNECTED:
+		if current == ClusterNodeStatus_OFFLOADED {
+			steps = append(steps, ClusterNodeStatus_CONNECTED)
+		}
+		steps = append(steps, ClusterNodeStatus_DISCONNECTED)
+	case ClusterNodeStatus_OFFLOADED:
+		if current != ClusterNodeStatus_DISCONNECTED {
+			steps = append(steps, ClusterNodeStatus_DISCONNECTED)
+		}
+		steps = append(steps, ClusterNodeStatus_OFFLOADED)
+	default:
+		return fmt.Errorf("invalid cluster node status : %s", string(status))
+	}
+
+	transitions := map[ClusterNodeStatus]ClusterNodeStatus{
+		ClusterNodeStatus_CONNECTED:    ClusterNodeStatus_CONNECTING,
+		ClusterNodeStatus_DISCONNECTED: ClusterNodeStatus_DISCONNECTING,
+		ClusterNodeStatus_OFFLOADED:    ClusterNodeStatus_OFFLOADING,
+	}
+	for _, step := range steps {
+		log.Printf("[INFO] Changing cluster node %s status to %s", nodeId, step)
+		err = c.UpdateClusterNodeStatus(nodeId, transitions[step])
+		if nil != err {
+			return err
+		}
+		err = c.WaitClusterNodeStatus(nodeId, step, max_wait)
+		if nil != err {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package nifi
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Component definition section
+
+type AllowableValue struct {
+	Value       string `json:"value"`
+	DisplayName string `json:"displayName"`
+	Description string `json:"description"`
+}
+
+// PropertyDependency makes a property relevant only when the property it names is set, to one of the dependent
+// values when any are given.
+type PropertyDependency struct {
+	PropertyName    string   `json:"propertyName"`
+	DependentValues []string `json:"dependentValues"`
+}
+
+type PropertyDescriptor struct {
+	Name                    string               `json:"name"`
+	DisplayName             string               `json:"displayName"`
+	Description             string               `json:"description"`
+	DefaultValue            string               `json:"defaultValue"`
+	AllowableValues         []AllowableValue     `json:"allowableValues"`
+	Required                bool                 `json:"required"`
+	Sensitive               bool                 `json:"sensitive"`
+	Dynamic                 bool                 `json:"dynamic"`
+	ExpressionLanguageScope string               `json:"expressionLanguageScope"`
+	TypeProvidedByValue     *DocumentedType      `json:"typeProvidedByValue,omitempty"`
+	Dependencies            []PropertyDependency `json:"dependencies"`
+}
+
+type Relationship struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type ComponentDefinition struct {
+	Type                         string                        `json:"type"`
+	Group                        string                        `json:"group"`
+	Artifact                     string                        `json:"artifact"`
+	Version                      string                        `json:"version"`
+	PropertyDescriptors          map[string]PropertyDescriptor `json:"propertyDescriptors"`
+	SupportedRelationships       []Relationship                `json:"supportedRelationships"`
+	SupportsDynamicProperties    bool                          `json:"supportsDynamicProperties"`
+	SupportsDynamicRelationships bool                          `json:"supportsDynamicRelationships"`
+}
+
+// ResolveBundle finds the bundle providing the type when the caller does not pin one.
+// Fields left empty in the given bundle match any bundle, the type must resolve to a single one.
+func (c *Client) ResolveBundle(kind ComponentKind, componentType string, bundle Bundle) (*Bundle, error) {
+	if bundle.Group != "" && bundle.Artifact != "" && bundle.Version != "" {
+		return &bundle, nil
+	}
+	types, err := c.GetComponentTypes(kind, bundle.Group, bundle.Artifact)
+	if nil != err {
+		return nil, err
+	}
+	var found *Bundle
+	for i, v := range types {
+		if v.Type != componentType {
+			continue
+		}
+		if bundle.Version != "" && v.Bundle.Version != bundle.Version {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("type %s is provided by several bundles, bundle coordinates are required", componentType)
+		}
+		found = &types[i].Bundle
+	}
+	if found == nil {
+		return nil, fmt.Errorf("not_found")
+	}
+	return found, nil
+}
+
+func (c *Client) GetComponentDefinition(kind ComponentKind, componentType string, bundle Bundle) (*ComponentDefinition, error) {
+	path := ""
+	switch kind {
+	case ComponentKind_PROCESSOR:
+		path = "processor-definition"
+	case ComponentKind_CONTROLLER_SERVICE:
+		path = "controller-service-definition"
+	case ComponentKind_REPORTING_TASK:
+		path = "reporting-task-definition"
+	default:
+		return nil, fmt.Errorf("invalid component kind : %s", string(kind))
+	}
+	resolved, err := c.ResolveBundle(kind, componentType, bundle)
+	if nil != err {
+		return nil, err
+	}
+	requestUrl := fmt.Sprintf("%s/flow/%s/%s/%s/%s/%s",
+		baseurl(c.Config), path, url.PathEscape(resolved.Group), url.PathEscape(resolved.Artifact),
+		url.PathEscape(resolved.Version), url.PathEscape(componentType))
+	definition := ComponentDefinition{}
+	code, err := c.JsonCall("GET", requestUrl, nil, &definition)
+	if code == 404 {
+		return nil, fmt.Errorf("not_found")
+	}
+	if nil != err {
+		return nil, err
+	}
+	if definition.Type == "" {
+		definition.Type = componentType
+	}
+	if definition.Group == "" {
+		definition.Group = resolved.Group
+		definition.Artifact = resolved.Artifact
+		definition.Version = resolved.Version
+	}
+	return &definition, nil
+}
+
+// FindRelationship looks up a relationship declared by the component type.
+func (definition *ComponentDefinition) FindRelationship(name string) *Relationship {
+	for i := range definition.SupportedRelationships {
+		if definition.SupportedRelationships[i].Name == name {
+			return &definition.SupportedRelationships[i]
+		}
+	}
+	return nil
+}
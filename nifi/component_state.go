@@ -0,0 +1,74 @@
+package nifi
+
+import (
+	"fmt"
+)
+
+// Component state section
+
+type StateEntry struct {
+	Key                string `json:"key"`
+	Value              string `json:"value"`
+	ClusterNodeId      string `json:"clusterNodeId,omitempty"`
+	ClusterNodeAddress string `json:"clusterNodeAddress,omitempty"`
+}
+
+type StateMap struct {
+	Scope           string       `json:"scope"`
+	TotalEntryCount int          `json:"totalEntryCount"`
+	State           []StateEntry `json:"state"`
+}
+
+type ComponentState struct {
+	ComponentState struct {
+		ComponentId      string    `json:"componentId"`
+		StateDescription string    `json:"stateDescription"`
+		ClusterState     *StateMap `json:"clusterState,omitempty"`
+		LocalState       *StateMap `json:"localState,omitempty"`
+	} `json:"componentState"`
+}
+
+func componentStateUrl(c *Client, kind ComponentKind, componentId string) (string, error) {
+	path := ""
+	switch kind {
+	case ComponentKind_PROCESSOR:
+		path = "processors"
+	case ComponentKind_CONTROLLER_SERVICE:
+		path = "controller-services"
+	case ComponentKind_REPORTING_TASK:
+		path = "reporting-tasks"
+	default:
+		return "", fmt.Errorf("invalid component kind : %s", string(kind))
+	}
+	return fmt.Sprintf("%s/%s/%s/state",
+		baseurl(c.Config), path, componentId), nil
+}
+
+func (c *Client) GetComponentState(kind ComponentKind, componentId string) (*ComponentState, error) {
+	url, err := componentStateUrl(c, kind, componentId)
+	if nil != err {
+		return nil, err
+	}
+	state := ComponentState{}
+	code, err := c.JsonCall("GET", url, nil, &state)
+	if code == 404 {
+		return nil, fmt.Errorf("not_found")
+	}
+	if nil != err {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// ClearComponentState clears the local and cluster state of a component, which must not be running.
+func (c *Client) ClearComponentState(kind ComponentKind, componentId string) error {
+	url, err := componentStateUrl(c, kind, componentId)
+	if nil != err {
+		return err
+	}
+	code, err := c.JsonCall("POST", url+"/clear-requests", nil, nil)
+	if code == 404 {
+		return fmt.Errorf("not_found")
+	}
+	return err
+}
@@ -0,0 +1,73 @@
+package nifi
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Component types section
+
+type ComponentKind string
+
+const (
+	ComponentKind_PROCESSOR          ComponentKind = "PROCESSOR"
+	ComponentKind_CONTROLLER_SERVICE ComponentKind = "CONTROLLER_SERVICE"
+	ComponentKind_REPORTING_TASK     ComponentKind = "REPORTING_TASK"
+)
+
+type Bundle struct {
+	Group    string `json:"group"`
+	Artifact string `json:"artifact"`
+	Version  string `json:"version"`
+}
+
+type DocumentedType struct {
+	Type        string   `json:"type"`
+	Bundle      Bundle   `json:"bundle"`
+	Description string   `json:"description"`
+	Restricted  bool     `json:"restricted"`
+	Tags        []string `json:"tags"`
+}
+
+type DocumentedTypes struct {
+	ProcessorTypes         []DocumentedType `json:"processorTypes"`
+	ControllerServiceTypes []DocumentedType `json:"controllerServiceTypes"`
+	ReportingTaskTypes     []DocumentedType `json:"reportingTaskTypes"`
+}
+
+// GetComponentTypes lists the types of the given kind available on the instance, optionally filtered by bundle.
+func (c *Client) GetComponentTypes(kind ComponentKind, bundleGroup string, bundleArtifact string) ([]DocumentedType, error) {
+	path := ""
+	switch kind {
+	case ComponentKind_PROCESSOR:
+		path = "processor-types"
+	case ComponentKind_CONTROLLER_SERVICE:
+		path = "controller-service-types"
+	case ComponentKind_REPORTING_TASK:
+		path = "reporting-task-types"
+	default:
+		return nil, fmt.Errorf("invalid component kind : %s", string(kind))
+	}
+	query := url.Values{}
+	if bundleGroup != "" {
+		query.Set("bundleGroupFilter", bundleGroup)
+	}
+	if bundleArtifact != "" {
+		query.Set("bundleArtifactFilter", bundleArtifact)
+	}
+	requestUrl := fmt.Sprintf("%s/flow/%s?%s",
+		baseurl(c.Config), path, query.Encode())
+	types := DocumentedTypes{}
+	_, err := c.JsonCall("GET", requestUrl, nil, &types)
+	if nil != err {
+		return nil, err
+	}
+	switch kind {
+	case ComponentKind_CONTROLLER_SERVICE:
+		return types.ControllerServiceTypes, nil
+	case ComponentKind_REPORTING_TASK:
+		return types.ReportingTaskTypes, nil
+	default:
+		return types.ProcessorTypes, nil
+	}
+}
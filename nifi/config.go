@@ -1,5 +1,7 @@
 package nifi
 
+import "time"
+
 // Config is the structure that stores the configuration to talk to a
 // NiFi API compatible host.
 type Config struct {
@@ -10,4 +12,6 @@ type Config struct {
 	HttpScheme    string
 	Username      string
 	Password      string
+	// Time to wait for bulletins after processors and controller services are applied, disabled when zero.
+	BulletinWarningDelay time.Duration
 }
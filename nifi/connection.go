@@ -3,6 +3,7 @@ package nifi
 import (
 	"fmt"
 	"log"
+	"strings"
 	"time"
 )
 
@@ -25,6 +26,18 @@ type ConnectionHand struct {
 	GroupId string              `json:"groupId"`
 }
 
+// IsRemotePort tells whether the hand is a port of a remote process group, in which case GroupId is the remote process group id.
+func (hand *ConnectionHand) IsRemotePort() bool {
+	return hand.Type == ConnectionHand_Type_REMOTE_INPUT_PORT || hand.Type == ConnectionHand_Type_REMOTE_OUTPUT_PORT
+}
+
+func (hand *ConnectionHand) RemotePortType() PortType {
+	if hand.Type == ConnectionHand_Type_REMOTE_OUTPUT_PORT {
+		return PortType_OUTPUT_PORT
+	}
+	return PortType_INPUT_PORT
+}
+
 type ConnectionComponent struct {
 	Id                            string         `json:"id,omitempty"`
 	ParentGroupId                 string         `json:"parentGroupId"`
@@ -45,6 +58,31 @@ type Connections struct {
 	Connections []Connection `json:"connections"`
 }
 
+type ConnectionStatusSnapshot struct {
+	FlowFilesQueued int    `json:"flowFilesQueued"`
+	BytesQueued     int64  `json:"bytesQueued"`
+	Queued          string `json:"queued"`
+	QueuedCount     string `json:"queuedCount"`
+	QueuedSize      string `json:"queuedSize"`
+	PercentUseCount int    `json:"percentUseCount"`
+	PercentUseBytes int    `json:"percentUseBytes"`
+	FlowFilesIn     int    `json:"flowFilesIn"`
+	BytesIn         int64  `json:"bytesIn"`
+	Input           string `json:"input"`
+	FlowFilesOut    int    `json:"flowFilesOut"`
+	BytesOut        int64  `json:"bytesOut"`
+	Output          string `json:"output"`
+}
+
+type ConnectionStatus struct {
+	ConnectionStatus struct {
+		Id                string                   `json:"id"`
+		GroupId           string                   `json:"groupId"`
+		Name              string                   `json:"name"`
+		AggregateSnapshot ConnectionStatusSnapshot `json:"aggregateSnapshot"`
+	} `json:"connectionStatus"`
+}
+
 type ConnectionDropRequest struct {
 	DropRequest struct {
 		Id       string `json:"id"`
@@ -52,6 +90,32 @@ type ConnectionDropRequest struct {
 	} `json:"dropRequest"`
 }
 
+type FlowFileSummary struct {
+	Uuid               string `json:"uuid"`
+	Filename           string `json:"filename"`
+	Position           int    `json:"position"`
+	Size               int64  `json:"size"`
+	QueuedDuration     int64  `json:"queuedDuration"`
+	LineageDuration    int64  `json:"lineageDuration"`
+	Penalized          bool   `json:"penalized"`
+	ClusterNodeId      string `json:"clusterNodeId"`
+	ClusterNodeAddress string `json:"clusterNodeAddress"`
+}
+
+type ConnectionListingRequest struct {
+	ListingRequest struct {
+		Id                string            `json:"id"`
+		Finished          bool              `json:"finished"`
+		FailureReason     string            `json:"failureReason"`
+		MaxResults        int               `json:"maxResults"`
+		FlowFileSummaries []FlowFileSummary `json:"flowFileSummaries"`
+		QueueSize         struct {
+			ByteCount   int64 `json:"byteCount"`
+			ObjectCount int   `json:"objectCount"`
+		} `json:"queueSize"`
+	} `json:"listingRequest"`
+}
+
 func (c *Client) CreateConnection(connection *Connection) error {
 	url := fmt.Sprintf("%s/process-groups/%s/connections",
 		baseurl(c.Config), connection.Component.ParentGroupId)
@@ -134,6 +198,107 @@ func (c *Client) DropConnectionData(connection *Connection) error {
 	return nil
 }
 
+// ListConnectionQueue lists the FlowFiles at the head of the queue. NiFi returns at most 100 of them.
+func (c *Client) ListConnectionQueue(connectionId string, max_wait time.Duration) (*ConnectionListingRequest, error) {
+	url := fmt.Sprintf("%s/flowfile-queues/%s/listing-requests",
+		baseurl(c.Config), connectionId)
+	listingRequest := ConnectionListingRequest{}
+	code, err := c.JsonCall("POST", url, nil, &listingRequest)
+	if code == 404 {
+		return nil, fmt.Errorf("not_found")
+	}
+	if nil != err {
+		return nil, err
+	}
+
+	url = fmt.Sprintf("%s/flowfile-queues/%s/listing-requests/%s",
+		baseurl(c.Config), connectionId, listingRequest.ListingRequest.Id)
+	defer func() {
+		_, err := c.JsonCall("DELETE", url, nil, nil)
+		if nil != err {
+			log.Printf("[WARN] Failed to delete listing request %s: %s", listingRequest.ListingRequest.Id, err)
+		}
+	}()
+
+	if !listingRequest.ListingRequest.Finished {
+		err = c.WaitUtil(max_wait, func(c *Client) bool {
+			_, err := c.JsonCall("GET", url, nil, &listingRequest)
+			if nil != err {
+				return false
+			}
+			log.Printf("[INFO] Listing Connection %s...", connectionId)
+			return listingRequest.ListingRequest.Finished
+		})
+		if nil != err {
+			return nil, fmt.Errorf("listing of connection %s did not complete in %s", connectionId, max_wait)
+		}
+	}
+	if listingRequest.ListingRequest.FailureReason != "" {
+		return nil, fmt.Errorf("listing of connection %s failed: %s", connectionId, listingRequest.ListingRequest.FailureReason)
+	}
+	return &listingRequest, nil
+}
+
+func (c *Client) GetConnectionStatus(connectionId string) (*ConnectionStatus, error) {
+	url := fmt.Sprintf("%s/flow/connections/%s/status",
+		baseurl(c.Config), connectionId)
+	status := ConnectionStatus{}
+	code, err := c.JsonCall("GET", url, nil, &status)
+	if code == 404 {
+		return nil, fmt.Errorf("not_found")
+	}
+	if nil != err {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// DrainConnection waits until the destination has consumed every FlowFile queued in the connection.
+// The caller is responsible for keeping the source stopped and the destination running.
+func (c *Client) DrainConnection(connection *Connection, timeout time.Duration) error {
+	connectionId := connection.Component.Id
+	queued := ""
+	err := c.WaitUtil(timeout, func(c *Client) bool {
+		status, err := c.GetConnectionStatus(connectionId)
+		if err != nil {
+			return false
+		}
+		snapshot := status.ConnectionStatus.AggregateSnapshot
+		queued = snapshot.Queued
+		log.Printf("[INFO] Draining Connection %s, queued: %s", connectionId, queued)
+		return snapshot.FlowFilesQueued == 0
+	})
+	if err != nil {
+		return fmt.Errorf("connection %s was not drained in %s, still queued: %s", connectionId, timeout, queued)
+	}
+	return nil
+}
+
+// WaitConnectionsEmpty waits until none of the connections has queued FlowFiles.
+func (c *Client) WaitConnectionsEmpty(connectionIds []string, timeout time.Duration) error {
+	pending := []string{}
+	err := c.WaitUtil(timeout, func(c *Client) bool {
+		pending = pending[:0]
+		for _, connectionId := range connectionIds {
+			status, err := c.GetConnectionStatus(connectionId)
+			if err != nil {
+				pending = append(pending, fmt.Sprintf("%s (%s)", connectionId, err))
+				continue
+			}
+			snapshot := status.ConnectionStatus.AggregateSnapshot
+			if snapshot.FlowFilesQueued > 0 {
+				pending = append(pending, fmt.Sprintf("%s (queued: %s)", connectionId, snapshot.Queued))
+			}
+		}
+		log.Printf("[INFO] Waiting for Connections to be empty: %v", pending)
+		return len(pending) == 0
+	})
+	if err != nil {
+		return fmt.Errorf("connections were not empty in %s: %s", timeout, strings.Join(pending, ", "))
+	}
+	return nil
+}
+
 func (c *Client) StopConnectionHand(connectionHand *ConnectionHand) error {
 	handType := connectionHand.Type
 	handId := connectionHand.Id
@@ -141,7 +306,7 @@ func (c *Client) StopConnectionHand(connectionHand *ConnectionHand) error {
 	switch handType {
 	case "PROCESSOR":
 		processor, err := c.GetProcessor(handId)
-		if err != nil {
+		if err == nil {
 			return c.StopProcessor(processor)
 		} else {
 			return err
@@ -162,13 +327,54 @@ func (c *Client) StopConnectionHand(connectionHand *ConnectionHand) error {
 			log.Printf("Fail to get Port %s", handId)
 			return err
 		}
+	case "REMOTE_INPUT_PORT", "REMOTE_OUTPUT_PORT":
+		return c.SetRemoteProcessGroupPortState(connectionHand.GroupId, handId, connectionHand.RemotePortType(), RemoteProcessGroupState_STOPPED)
 	case "FUNNEL":
 		log.Printf("No need to stop Funnel")
 		return nil
 	default:
-		log.Fatalf("[WARN]: not supported connection source/target type : %s", handType)
+		return fmt.Errorf("not supported connection source/target type : %s", handType)
+	}
+}
+
+// ConnectionHandRunning tells whether the component at the end of a connection is running.
+// Funnels cannot be stopped and are never reported as running.
+func (c *Client) ConnectionHandRunning(connectionHand *ConnectionHand) (bool, error) {
+	handId := connectionHand.Id
+	switch connectionHand.Type {
+	case ConnectionHand_Type_PROCESSOR:
+		processor, err := c.GetProcessor(handId)
+		if err != nil {
+			return false, err
+		}
+		return processor.Component.State == "RUNNING", nil
+	case ConnectionHand_Type_INPUT_PORT, ConnectionHand_Type_OUTPUT_PORT:
+		port, err := c.GetPort(handId, PortType(connectionHand.Type))
+		if err != nil {
+			return false, err
+		}
+		return port.Component.State == PortState_RUNNING, nil
+	case ConnectionHand_Type_REMOTE_INPUT_PORT, ConnectionHand_Type_REMOTE_OUTPUT_PORT:
+		processGroup, err := c.GetRemoteProcessGroup(connectionHand.GroupId)
+		if err != nil {
+			return false, err
+		}
+		port := processGroup.FindPortById(handId, connectionHand.RemotePortType())
+		return port != nil && port.Transmitting != nil && *port.Transmitting, nil
+	case ConnectionHand_Type_FUNNEL:
+		return false, nil
+	default:
+		return false, fmt.Errorf("not supported connection source/target type : %s", connectionHand.Type)
 	}
-	return nil
+}
+
+// startConnectionPort starts a port linked to a connection, disabled ports are kept disabled.
+func (c *Client) startConnectionPort(port *Port) error {
+	if port.Component.State == PortState_DISABLED {
+		log.Printf("[DEBUG] Port %s is disabled, not starting it", port.Component.Id)
+		return nil
+	}
+	return c.StartPort(port)
 }
 
 func (c *Client) StartConnectionHand(connectionHand *ConnectionHand) error {
@@ -178,7 +384,7 @@ func (c *Client) StartConnectionHand(connectionHand *ConnectionHand) error {
 	switch handType {
 	case "PROCESSOR":
 		processor, err := c.GetProcessor(handId)
-		if err != nil {
+		if err == nil {
 			return c.StartProcessor(processor)
 		} else {
 			return err
@@ -186,22 +392,23 @@ func (c *Client) StartConnectionHand(connectionHand *ConnectionHand) error {
 	case "INPUT_PORT":
 		port, err := c.GetPort(handId, "INPUT_PORT")
 		if err == nil {
-			return c.StartPort(port)
+			return c.startConnectionPort(port)
 		} else {
 			return err
 		}
 	case "OUTPUT_PORT":
 		port, err := c.GetPort(handId, "OUTPUT_PORT")
 		if err == nil {
-			return c.StartPort(port)
+			return c.startConnectionPort(port)
 		} else {
 			return err
 		}
+	case "REMOTE_INPUT_PORT", "REMOTE_OUTPUT_PORT":
+		return c.SetRemoteProcessGroupPortState(connectionHand.GroupId, handId, connectionHand.RemotePortType(), RemoteProcessGroupState_TRANSMITTING)
 	case "FUNNEL":
 		log.Printf("No need to start Funnel")
 		return nil
 	default:
-		log.Printf("[WARN]: not supported connection source/target type : %s", handType)
+		return fmt.Errorf("not supported connection source/target type : %s", handType)
 	}
-	return nil
 }
@@ -2,6 +2,7 @@ package nifi
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -95,6 +96,15 @@ func TestClientConnection(t *testing.T) {
 	err = client.CreateConnection(&connection)
 	assert.Nil(t, err)
 	assert.NotEmpty(t, connection.Component.Id)
+
+	status, err := client.GetConnectionStatus(connection.Component.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, status.ConnectionStatus.AggregateSnapshot.FlowFilesQueued)
+
+	listingRequest, err := client.ListConnectionQueue(connection.Component.Id, 30*time.Second)
+	assert.Nil(t, err)
+	assert.Empty(t, listingRequest.ListingRequest.FlowFileSummaries)
+
 	connection.Component.BackPressureObjectThreshold = 2000
 
 	err = client.UpdateConnection(&connection)
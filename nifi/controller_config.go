@@ -0,0 +1,41 @@
+package nifi
+
+import (
+	"fmt"
+)
+
+// Controller configuration section
+
+// Thread pool sizes NiFi starts with when nothing was configured.
+const (
+	ControllerConfigDefaultMaxTimerDrivenThreadCount = 10
+	ControllerConfigDefaultMaxEventDrivenThreadCount = 1
+)
+
+type ControllerConfigComponent struct {
+	MaxTimerDrivenThreadCount int `json:"maxTimerDrivenThreadCount"`
+	MaxEventDrivenThreadCount int `json:"maxEventDrivenThreadCount"`
+}
+
+type ControllerConfig struct {
+	Revision  Revision                  `json:"revision"`
+	Component ControllerConfigComponent `json:"component"`
+}
+
+func (c *Client) GetControllerConfig() (*ControllerConfig, error) {
+	url := fmt.Sprintf("%s/controller/config",
+		baseurl(c.Config))
+	config := ControllerConfig{}
+	_, err := c.JsonCall("GET", url, nil, &config)
+	if nil != err {
+		return nil, err
+	}
+	return &config, nil
+}
+
+func (c *Client) UpdateControllerConfig(config *ControllerConfig) error {
+	url := fmt.Sprintf("%s/controller/config",
+		baseurl(c.Config))
+	_, err := c.JsonCall("PUT", url, config, config)
+	return err
+}
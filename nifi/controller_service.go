@@ -22,6 +22,9 @@ type ControllerServiceComponent struct {
 	State         ControllerServiceState `json:"state,omitempty"`
 	expectState   ControllerServiceState
 	Properties    map[string]interface{} `json:"properties"`
+
+	ValidationStatus ValidationStatus `json:"validationStatus,omitempty"`
+	ValidationErrors []string         `json:"validationErrors,omitempty"`
 }
 
 type ControllerService struct {
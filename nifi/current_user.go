@@ -0,0 +1,123 @@
+package nifi
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Current user section
+
+type Permissions struct {
+	CanRead  bool `json:"canRead"`
+	CanWrite bool `json:"canWrite"`
+}
+
+type CurrentUser struct {
+	Identity                        string      `json:"identity"`
+	Anonymous                       bool        `json:"anonymous"`
+	CanVersionFlows                 bool        `json:"canVersionFlows"`
+	ControllerPermissions           Permissions `json:"controllerPermissions"`
+	PoliciesPermissions             Permissions `json:"policiesPermissions"`
+	TenantsPermissions              Permissions `json:"tenantsPermissions"`
+	ProvenancePermissions           Permissions `json:"provenancePermissions"`
+	CountersPermissions             Permissions `json:"countersPermissions"`
+	SystemPermissions               Permissions `json:"systemPermissions"`
+	RestrictedComponentsPermissions Permissions `json:"restrictedComponentsPermissions"`
+	ParameterContextPermissions     Permissions `json:"parameterContextPermissions"`
+}
+
+func (c *Client) GetCurrentUser() (*CurrentUser, error) {
+	url := fmt.Sprintf("%s/flow/current-user",
+		baseurl(c.Config))
+	user := CurrentUser{}
+	code, err := c.JsonCall("GET", url, nil, &user)
+	if code == 401 {
+		return nil, fmt.Errorf("authentication failed: %s", err)
+	}
+	if nil != err {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// Permission denied section
+
+type PermissionDeniedError struct {
+	Method  string
+	Url     string
+	Action  string
+	Policy  string
+	Message string
+}
+
+func (e *PermissionDeniedError) Error() string {
+	return fmt.Sprintf("permission denied: %s %s requires %s access to %s: %s", e.Method, e.Url, e.Action, e.Policy, e.Message)
+}
+
+type permissionDenials struct {
+	sync.Mutex
+	errors []*PermissionDeniedError
+}
+
+// TrackPermissions returns a copy of the client recording the permission denied errors of its calls,
+// so that they can be reported even when the caller does not return the underlying error.
+func (c *Client) TrackPermissions() *Client {
+	tracked := *c
+	tracked.denied = &permissionDenials{}
+	return &tracked
+}
+
+// PermissionDenied lists the permission denied errors recorded since TrackPermissions.
+func (c *Client) PermissionDenied() []*PermissionDeniedError {
+	if c.denied == nil {
+		return nil
+	}
+	c.denied.Lock()
+	defer c.denied.Unlock()
+	return append([]*PermissionDeniedError{}, c.denied.errors...)
+}
+
+func (c *Client) permissionDenied(method string, url string, message string) error {
+	action := "write"
+	if method == "GET" {
+		action = "read"
+	}
+	path := strings.TrimPrefix(url, baseurl(c.Config))
+	path = strings.SplitN(path, "?", 2)[0]
+	err := &PermissionDeniedError{
+		Method:  method,
+		Url:     path,
+		Action:  action,
+		Policy:  permissionPolicy(path),
+		Message: message,
+	}
+	if c.denied != nil {
+		c.denied.Lock()
+		c.denied.errors = append(c.denied.errors, err)
+		c.denied.Unlock()
+	}
+	return err
+}
+
+// permissionPolicy maps an API path to the resource of the access policy guarding it.
+func permissionPolicy(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	switch segments[0] {
+	case "flow":
+		return "/flow"
+	case "controller", "tenants", "policies", "counters", "system-diagnostics", "provenance":
+		return "/" + segments[0]
+	case "provenance-events":
+		return "/provenance"
+	case "flowfile-queues":
+		if len(segments) > 1 {
+			return "/data of connection " + segments[1]
+		}
+		return "/data"
+	}
+	if len(segments) > 1 {
+		return "/" + segments[0] + "/" + segments[1]
+	}
+	return "/" + segments[0]
+}
@@ -0,0 +1,38 @@
+package nifi
+
+import "fmt"
+
+type LabelComponent struct {
+	Id            string    `json:"id,omitempty"`
+	ParentGroupId string    `json:"parentGroupId,omitempty"`
+	Label         string    `json:"label"`
+	Width         float64   `json:"width,omitempty"`
+	Height        float64   `json:"height,omitempty"`
+	Position      *Position `json:"position,omitempty"`
+}
+
+type Label struct {
+	Revision  Revision       `json:"revision"`
+	Component LabelComponent `json:"component"`
+}
+
+func (c *Client) GetLabel(labelId string) (*Label, error) {
+	url := fmt.Sprintf("%s/labels/%s",
+		baseurl(c.Config), labelId)
+	label := Label{}
+	code, err := c.JsonCall("GET", url, nil, &label)
+	if code == 404 {
+		return nil, fmt.Errorf("not_found")
+	}
+	if nil != err {
+		return nil, err
+	}
+	return &label, nil
+}
+
+func (c *Client) DeleteLabel(label *Label) error {
+	url := fmt.Sprintf("%s/labels/%s?version=%d",
+		baseurl(c.Config), label.Component.Id, label.Revision.Version)
+	_, err := c.JsonCall("DELETE", url, nil, nil)
+	return err
+}
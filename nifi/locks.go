@@ -0,0 +1,75 @@
+package nifi
+
+import (
+	"sort"
+	"sync"
+)
+
+// Locks section
+
+type keyedLock struct {
+	sync.Mutex
+	refs int
+}
+
+// keyedLocks holds a mutex per key, created on demand and removed once nobody holds or waits for it.
+type keyedLocks struct {
+	sync.Mutex
+	locks map[string]*keyedLock
+}
+
+func newKeyedLocks() *keyedLocks {
+	return &keyedLocks{
+		locks: map[string]*keyedLock{},
+	}
+}
+
+func (l *keyedLocks) acquire(key string) {
+	l.Lock()
+	lock, ok := l.locks[key]
+	if !ok {
+		lock = &keyedLock{}
+		l.locks[key] = lock
+	}
+	lock.refs++
+	l.Unlock()
+
+	lock.Lock()
+}
+
+func (l *keyedLocks) release(key string) {
+	l.Lock()
+	lock := l.locks[key]
+	lock.refs--
+	if lock.refs == 0 {
+		delete(l.locks, key)
+	}
+	l.Unlock()
+
+	lock.Unlock()
+}
+
+// LockKeys blocks until every key is held and returns the function releasing them. Keys are acquired in a
+// consistent order so that concurrent callers sharing some keys cannot deadlock, empty keys are ignored.
+// Callers must release their keys before locking other ones.
+func (c *Client) LockKeys(keys ...string) func() {
+	unique := map[string]bool{}
+	sorted := []string{}
+	for _, key := range keys {
+		if key == "" || unique[key] {
+			continue
+		}
+		unique[key] = true
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+
+	for _, key := range sorted {
+		c.locks.acquire(key)
+	}
+	return func() {
+		for i := len(sorted) - 1; i >= 0; i-- {
+			c.locks.release(sorted[i])
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package nifi
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientLockKeys(t *testing.T) {
+	client := &Client{locks: newKeyedLocks()}
+
+	// Disjoint keys do not wait for each other
+	unlock := client.LockKeys("group-a", "processor-a")
+	acquired := make(chan bool)
+	go func() {
+		unlockOther := client.LockKeys("group-b", "processor-b", "")
+		unlockOther()
+		acquired <- true
+	}()
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("disjoint keys were serialized")
+	}
+
+	// Shared keys wait until released
+	go func() {
+		unlockOther := client.LockKeys("processor-b", "group-a")
+		unlockOther()
+		acquired <- true
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("shared key was acquired twice")
+	case <-time.After(100 * time.Millisecond):
+	}
+	unlock()
+	<-acquired
+
+	// Overlapping key sets locked in any order do not deadlock
+	wg := sync.WaitGroup{}
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			client.LockKeys("a", "b", "c")()
+		}()
+		go func() {
+			defer wg.Done()
+			client.LockKeys("c", "b", "a", "a")()
+		}()
+	}
+	wg.Wait()
+	assert.Empty(t, client.locks.locks)
+}
@@ -3,6 +3,7 @@ package nifi
 import (
 	"fmt"
 	"log"
+	"sync"
 	"time"
 )
 
@@ -20,6 +21,14 @@ type PortComponent struct {
 	Position      Position  `json:"position"`
 	State         PortState `json:"state,omitempty"`
 	expectState   PortState
+
+	ConcurrentlySchedulableTaskCount int `json:"concurrentlySchedulableTaskCount,omitempty"`
+	// Public site-to-site ports, NiFi 1.10 or later
+	AllowRemoteAccess  *bool    `json:"allowRemoteAccess,omitempty"`
+	UserAccessControl  []string `json:"userAccessControl,omitempty"`
+	GroupAccessControl []string `json:"groupAccessControl,omitempty"`
+
+	ValidationErrors []string `json:"validationErrors,omitempty"`
 }
 
 type PortStateComponent struct {
@@ -185,3 +194,51 @@ func (c *Client) StopPort(port *Port) error {
 func (c *Client) DisablePort(port *Port) error {
 	return c.SetPortState(port, PortState_DISABLED)
 }
+
+// SetPortDesiredState moves the port to the given state, disabled ports are stopped before they are started
+// and running ports are stopped before they are disabled.
+func (c *Client) SetPortDesiredState(port *Port, state PortState) error {
+	current := port.Component.State
+	if current == state {
+		return nil
+	}
+	if current != PortState_STOPPED && state != PortState_STOPPED {
+		err := c.StopPort(port)
+		if nil != err {
+			return err
+		}
+	}
+	return c.SetPortState(port, state)
+}
+
+// portStarts records the ports kept stopped until a connection makes them valid.
+type portStarts struct {
+	sync.Mutex
+	deferred map[string]bool
+}
+
+func newPortStarts() *portStarts {
+	return &portStarts{
+		deferred: map[string]bool{},
+	}
+}
+
+// SetPortStartDeferred records whether the port should be started once a connection makes it valid.
+func (c *Client) SetPortStartDeferred(portId string, deferred bool) {
+	c.portStarts.Lock()
+	defer c.portStarts.Unlock()
+	if deferred {
+		c.portStarts.deferred[portId] = true
+	} else {
+		delete(c.portStarts.deferred, portId)
+	}
+}
+
+// TakePortStartDeferred tells whether the start of the port was deferred and clears it.
+func (c *Client) TakePortStartDeferred(portId string) bool {
+	c.portStarts.Lock()
+	defer c.portStarts.Unlock()
+	deferred := c.portStarts.deferred[portId]
+	delete(c.portStarts.deferred, portId)
+	return deferred
+}
@@ -98,6 +98,19 @@ func TestClientInputPort(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, inputPort.Component.State, PortState_DISABLED)
 
+	err = client.SetPortDesiredState(&inputPort, PortState_RUNNING)
+	assert.Nil(t, err)
+	assert.Equal(t, inputPort.Component.State, PortState_RUNNING)
+
+	err = client.SetPortDesiredState(&inputPort, PortState_DISABLED)
+	assert.Nil(t, err)
+	assert.Equal(t, inputPort.Component.State, PortState_DISABLED)
+
+	inputPort.Component.ConcurrentlySchedulableTaskCount = 2
+	err = client.UpdatePort(&inputPort)
+	assert.Nil(t, err)
+	assert.Equal(t, inputPort.Component.ConcurrentlySchedulableTaskCount, 2)
+
 	err = client.DeleteConnection(&connection)
 	assert.Equal(t, err, nil)
 
@@ -2,6 +2,8 @@ package nifi
 
 import (
 	"fmt"
+	"log"
+	"time"
 )
 
 // Processor section
@@ -39,13 +41,36 @@ type ProcessorComponent struct {
 	State         string                  `json:"state,omitempty"`
 	Config        *ProcessorConfig        `json:"config,omitempty"`
 	Relationships []ProcessorRelationship `json:"relationships,omitempty"`
+
+	ValidationStatus ValidationStatus `json:"validationStatus,omitempty"`
+	ValidationErrors []string         `json:"validationErrors,omitempty"`
+}
+
+type ProcessorStatus struct {
+	RunStatus         string `json:"runStatus,omitempty"`
+	AggregateSnapshot struct {
+		ActiveThreadCount     int `json:"activeThreadCount"`
+		TerminatedThreadCount int `json:"terminatedThreadCount"`
+	} `json:"aggregateSnapshot"`
 }
 
 type Processor struct {
 	Revision  Revision           `json:"revision"`
 	Component ProcessorComponent `json:"component"`
+	Status    *ProcessorStatus   `json:"status,omitempty"`
+}
+
+// ActiveThreadCount returns the number of threads still running the processor, stopped processors included.
+func (processor *Processor) ActiveThreadCount() int {
+	if processor.Status == nil {
+		return 0
+	}
+	return processor.Status.AggregateSnapshot.ActiveThreadCount
 }
 
+// Default time StopProcessorWithTimeout is given to wait for active threads by processor resources.
+const ProcessorDefaultStopTimeout = 2 * time.Minute
+
 func ProcessorStub() *Processor {
 	return &Processor{
 		Component: ProcessorComponent{
@@ -138,6 +163,59 @@ func (c *Client) StartProcessor(processor *Processor) error {
 	return c.SetProcessorState(processor, "RUNNING")
 }
 
+// StopProcessor stops the processor without waiting for its active threads, see StopProcessorWithTimeout.
 func (c *Client) StopProcessor(processor *Processor) error {
 	return c.SetProcessorState(processor, "STOPPED")
 }
+
+// StopProcessorWithTimeout stops the processor if it is running and waits for its active threads to complete.
+// When they are still active after max_wait, they are terminated if terminate is set, otherwise an error is returned.
+func (c *Client) StopProcessorWithTimeout(processor *Processor, max_wait time.Duration, terminate bool) error {
+	if "RUNNING" == processor.Component.State {
+		err := c.StopProcessor(processor)
+		if nil != err {
+			return err
+		}
+	}
+	err := c.WaitProcessorThreads(processor.Component.Id, max_wait)
+	if nil == err || !terminate {
+		return err
+	}
+
+	log.Printf("[WARN] Terminating threads of Processor %s: %s", processor.Component.Id, err)
+	err = c.TerminateProcessorThreads(processor)
+	if nil != err {
+		return err
+	}
+	return c.WaitProcessorThreads(processor.Component.Id, max_wait)
+}
+
+// WaitProcessorThreads waits until the processor is not running and has no active thread.
+func (c *Client) WaitProcessorThreads(processorId string, max_wait time.Duration) error {
+	active := 0
+	err := c.WaitUtil(max_wait, func(c *Client) bool {
+		current, err := c.GetProcessor(processorId)
+		if err != nil {
+			return false
+		}
+		active = current.ActiveThreadCount()
+		log.Printf("[INFO] Waiting for Processor %s to stop, %d active threads", processorId, active)
+		return current.Component.State != "RUNNING" && active == 0
+	})
+	if err != nil {
+		return fmt.Errorf("processor %s still has %d active threads after %s", processorId, active, max_wait)
+	}
+	return nil
+}
+
+// TerminateProcessorThreads terminates the active threads of a stopped processor.
+func (c *Client) TerminateProcessorThreads(processor *Processor) error {
+	url := fmt.Sprintf("%s/processors/%s/threads",
+		baseurl(c.Config), processor.Component.Id)
+	_, err := c.JsonCall("DELETE", url, nil, processor)
+	if nil != err {
+		return err
+	}
+	c.CleanupNilProperties(processor.Component.Config.Properties)
+	return nil
+}
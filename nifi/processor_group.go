@@ -1,14 +1,61 @@
 package nifi
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // Process Group section
 
+type ParameterContextReference struct {
+	Id        string `json:"id"`
+	Component *struct {
+		Id   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"component,omitempty"`
+}
+
+type ProcessGroupFlowFileConcurrency string
+
+const (
+	ProcessGroupFlowFileConcurrency_UNBOUNDED                ProcessGroupFlowFileConcurrency = "UNBOUNDED"
+	ProcessGroupFlowFileConcurrency_SINGLE_FLOWFILE_PER_NODE ProcessGroupFlowFileConcurrency = "SINGLE_FLOWFILE_PER_NODE"
+	ProcessGroupFlowFileConcurrency_SINGLE_BATCH_PER_NODE    ProcessGroupFlowFileConcurrency = "SINGLE_BATCH_PER_NODE"
+)
+
+type ProcessGroupFlowFileOutboundPolicy string
+
+const (
+	ProcessGroupFlowFileOutboundPolicy_STREAM_WHEN_AVAILABLE ProcessGroupFlowFileOutboundPolicy = "STREAM_WHEN_AVAILABLE"
+	ProcessGroupFlowFileOutboundPolicy_BATCH_OUTPUT          ProcessGroupFlowFileOutboundPolicy = "BATCH_OUTPUT"
+)
+
+type ProcessGroupExecutionEngine string
+
+const (
+	ProcessGroupExecutionEngine_INHERITED ProcessGroupExecutionEngine = "INHERITED"
+	ProcessGroupExecutionEngine_STANDARD  ProcessGroupExecutionEngine = "STANDARD"
+	ProcessGroupExecutionEngine_STATELESS ProcessGroupExecutionEngine = "STATELESS"
+)
+
 type ProcessGroupComponent struct {
-	Id            string   `json:"id,omitempty"`
-	ParentGroupId string   `json:"parentGroupId"`
-	Name          string   `json:"name"`
-	Position      Position `json:"position"`
+	Id               string                     `json:"id,omitempty"`
+	ParentGroupId    string                     `json:"parentGroupId"`
+	Name             string                     `json:"name"`
+	Comments         string                     `json:"comments"`
+	Position         Position                   `json:"position"`
+	ParameterContext *ParameterContextReference `json:"parameterContext,omitempty"`
+
+	// Settings below are omitted when empty, older NiFi versions do not support all of them
+	FlowFileConcurrency                  ProcessGroupFlowFileConcurrency    `json:"flowfileConcurrency,omitempty"`
+	FlowFileOutboundPolicy               ProcessGroupFlowFileOutboundPolicy `json:"flowfileOutboundPolicy,omitempty"`
+	DefaultFlowFileExpiration            string                             `json:"defaultFlowFileExpiration,omitempty"`
+	DefaultBackPressureObjectThreshold   *int                               `json:"defaultBackPressureObjectThreshold,omitempty"`
+	DefaultBackPressureDataSizeThreshold string                             `json:"defaultBackPressureDataSizeThreshold,omitempty"`
+	LogFileSuffix                        *string                            `json:"logFileSuffix,omitempty"`
+	ExecutionEngine                      ProcessGroupExecutionEngine        `json:"executionEngine,omitempty"`
+	MaxConcurrentTasks                   int                                `json:"maxConcurrentTasks,omitempty"`
+	StatelessFlowTimeout                 string                             `json:"statelessFlowTimeout,omitempty"`
 }
 
 type ProcessGroup struct {
@@ -51,6 +98,46 @@ func (c *Client) DeleteProcessGroup(processGroup *ProcessGroup) error {
 	return err
 }
 
+type ProcessGroups struct {
+	ProcessGroups []ProcessGroup `json:"processGroups"`
+}
+
+func (c *Client) GetChildProcessGroups(processGroupId string) (*ProcessGroups, error) {
+	url := fmt.Sprintf("%s/process-groups/%s/process-groups",
+		baseurl(c.Config), processGroupId)
+	processGroups := ProcessGroups{}
+	code, err := c.JsonCall("GET", url, nil, &processGroups)
+	if code == 404 {
+		return nil, fmt.Errorf("not_found")
+	}
+	if nil != err {
+		return nil, err
+	}
+	return &processGroups, nil
+}
+
+// FindChildProcessGroup looks up a direct child of the process group by name.
+func (c *Client) FindChildProcessGroup(parentGroupId string, name string) (*ProcessGroup, error) {
+	processGroups, err := c.GetChildProcessGroups(parentGroupId)
+	if nil != err {
+		return nil, err
+	}
+	var found *ProcessGroup
+	for i := range processGroups.ProcessGroups {
+		if processGroups.ProcessGroups[i].Component.Name != name {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("more than one Process Group named %s in %s", name, parentGroupId)
+		}
+		found = &processGroups.ProcessGroups[i]
+	}
+	if found == nil {
+		return nil, fmt.Errorf("not_found")
+	}
+	return found, nil
+}
+
 func (c *Client) GetProcessGroupConnections(processGroupId string) (*Connections, error) {
 	url := fmt.Sprintf("%s/process-groups/%s/connections",
 		baseurl(c.Config), processGroupId)
@@ -61,3 +148,152 @@ func (c *Client) GetProcessGroupConnections(processGroupId string) (*Connections
 	}
 	return &connections, nil
 }
+
+type ProcessGroupFlowContents struct {
+	ProcessGroups       []ProcessGroup       `json:"processGroups"`
+	RemoteProcessGroups []RemoteProcessGroup `json:"remoteProcessGroups"`
+	Processors          []Processor          `json:"processors"`
+	InputPorts          []Port               `json:"inputPorts"`
+	OutputPorts         []Port               `json:"outputPorts"`
+	Connections         []Connection         `json:"connections"`
+	Labels              []Label              `json:"labels"`
+	Funnels             []Funnel             `json:"funnels"`
+}
+
+type ProcessGroupFlow struct {
+	ProcessGroupFlow struct {
+		Id            string                   `json:"id"`
+		ParentGroupId string                   `json:"parentGroupId"`
+		Flow          ProcessGroupFlowContents `json:"flow"`
+	} `json:"processGroupFlow"`
+}
+
+type ProcessGroupStatusSnapshot struct {
+	FlowFilesQueued   int    `json:"flowFilesQueued"`
+	BytesQueued       int64  `json:"bytesQueued"`
+	Queued            string `json:"queued"`
+	ActiveThreadCount int    `json:"activeThreadCount"`
+}
+
+type ProcessGroupStatus struct {
+	ProcessGroupStatus struct {
+		Id                string                     `json:"id"`
+		AggregateSnapshot ProcessGroupStatusSnapshot `json:"aggregateSnapshot"`
+	} `json:"processGroupStatus"`
+}
+
+type ProcessGroupScheduleState string
+
+const (
+	ProcessGroupScheduleState_RUNNING ProcessGroupScheduleState = "RUNNING"
+	ProcessGroupScheduleState_STOPPED ProcessGroupScheduleState = "STOPPED"
+)
+
+type ProcessGroupSchedule struct {
+	Id    string                    `json:"id"`
+	State ProcessGroupScheduleState `json:"state"`
+}
+
+type ProcessGroupControllerServicesActivation struct {
+	Id    string                 `json:"id"`
+	State ControllerServiceState `json:"state"`
+}
+
+type ControllerServices struct {
+	ControllerServices []ControllerService `json:"controllerServices"`
+}
+
+func (c *Client) GetProcessGroupFlow(processGroupId string) (*ProcessGroupFlow, error) {
+	url := fmt.Sprintf("%s/flow/process-groups/%s",
+		baseurl(c.Config), processGroupId)
+	flow := ProcessGroupFlow{}
+	code, err := c.JsonCall("GET", url, nil, &flow)
+	if code == 404 {
+		return nil, fmt.Errorf("not_found")
+	}
+	if nil != err {
+		return nil, err
+	}
+	return &flow, nil
+}
+
+func (c *Client) GetProcessGroupStatus(processGroupId string) (*ProcessGroupStatus, error) {
+	url := fmt.Sprintf("%s/flow/process-groups/%s/status",
+		baseurl(c.Config), processGroupId)
+	status := ProcessGroupStatus{}
+	code, err := c.JsonCall("GET", url, nil, &status)
+	if code == 404 {
+		return nil, fmt.Errorf("not_found")
+	}
+	if nil != err {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// ScheduleProcessGroupComponents starts or stops every processor and port of the group and its descendants.
+func (c *Client) ScheduleProcessGroupComponents(processGroupId string, state ProcessGroupScheduleState) error {
+	url := fmt.Sprintf("%s/flow/process-groups/%s",
+		baseurl(c.Config), processGroupId)
+	schedule := ProcessGroupSchedule{
+		Id:    processGroupId,
+		State: state,
+	}
+	_, err := c.JsonCall("PUT", url, schedule, nil)
+	return err
+}
+
+func (c *Client) GetProcessGroupControllerServices(processGroupId string) (*ControllerServices, error) {
+	url := fmt.Sprintf("%s/flow/process-groups/%s/controller-services?includeAncestorGroups=false&includeDescendantGroups=true",
+		baseurl(c.Config), processGroupId)
+	controllerServices := ControllerServices{}
+	_, err := c.JsonCall("GET", url, nil, &controllerServices)
+	if nil != err {
+		return nil, err
+	}
+	return &controllerServices, nil
+}
+
+// SetProcessGroupControllerServicesState enables or disables every controller service of the group and
+// its descendants, then waits for all of them to reach the state.
+func (c *Client) SetProcessGroupControllerServicesState(processGroupId string, state ControllerServiceState) error {
+	url := fmt.Sprintf("%s/flow/process-groups/%s/controller-services",
+		baseurl(c.Config), processGroupId)
+	activation := ProcessGroupControllerServicesActivation{
+		Id:    processGroupId,
+		State: state,
+	}
+	_, err := c.JsonCall("PUT", url, activation, nil)
+	if nil != err {
+		return err
+	}
+	return c.WaitUtil(120*time.Second, func(c *Client) bool {
+		controllerServices, err := c.GetProcessGroupControllerServices(processGroupId)
+		if err != nil {
+			return false
+		}
+		for _, controllerService := range controllerServices.ControllerServices {
+			if controllerService.Component.State != state {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// GetProcessGroupFlows returns the flow of the group followed by the flows of all of its descendants.
+func (c *Client) GetProcessGroupFlows(processGroupId string) ([]ProcessGroupFlow, error) {
+	flow, err := c.GetProcessGroupFlow(processGroupId)
+	if nil != err {
+		return nil, err
+	}
+	flows := []ProcessGroupFlow{*flow}
+	for _, child := range flow.ProcessGroupFlow.Flow.ProcessGroups {
+		childFlows, err := c.GetProcessGroupFlows(child.Component.Id)
+		if nil != err {
+			return nil, err
+		}
+		flows = append(flows, childFlows...)
+	}
+	return flows, nil
+}
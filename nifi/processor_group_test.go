@@ -36,3 +36,43 @@ func TestClientProcessGroup(t *testing.T) {
 	err = client.DeleteProcessGroup(&processGroup)
 	assert.Equal(t, err, nil)
 }
+
+func TestClientProcessGroupEmpty(t *testing.T) {
+	client := setup()
+	processGroup := ProcessGroup{
+		Revision: Revision{
+			Version: 0,
+		},
+		Component: ProcessGroupComponent{
+			ParentGroupId: "root",
+			Name:          "force_destroy",
+			Position: Position{
+				X: 0,
+				Y: 5,
+			},
+		},
+	}
+	err := client.CreateProcessGroup(&processGroup)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, processGroup.Component.Id)
+
+	flows, err := client.GetProcessGroupFlows(processGroup.Component.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(flows))
+	assert.Equal(t, processGroup.Component.Id, flows[0].ProcessGroupFlow.Id)
+
+	err = client.ScheduleProcessGroupComponents(processGroup.Component.Id, ProcessGroupScheduleState_STOPPED)
+	assert.Nil(t, err)
+
+	err = client.SetProcessGroupControllerServicesState(processGroup.Component.Id, ControllerServiceState_DISABLED)
+	assert.Nil(t, err)
+
+	status, err := client.GetProcessGroupStatus(processGroup.Component.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, status.ProcessGroupStatus.AggregateSnapshot.FlowFilesQueued)
+
+	processGroup2, err := client.GetProcessGroup(processGroup.Component.Id)
+	assert.Nil(t, err)
+	err = client.DeleteProcessGroup(processGroup2)
+	assert.Nil(t, err)
+}
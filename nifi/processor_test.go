@@ -2,6 +2,7 @@ package nifi
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -55,9 +56,75 @@ func TestClientProcessor(t *testing.T) {
 	err = client.StartProcessor(&processor)
 	assert.Nil(t, err)
 
-	err = client.StopProcessor(&processor)
+	err = client.StopProcessorWithTimeout(&processor, time.Minute, false)
+	assert.Nil(t, err)
+
+	state, err := client.GetComponentState(ComponentKind_PROCESSOR, processor.Component.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, processor.Component.Id, state.ComponentState.ComponentId)
+
+	err = client.ClearComponentState(ComponentKind_PROCESSOR, processor.Component.Id)
 	assert.Nil(t, err)
 
 	err = client.DeleteProcessor(&processor)
 	assert.Nil(t, err)
 }
+
+func TestClientProcessorTerminateThreads(t *testing.T) {
+	client := setup()
+
+	// The thread keeps waiting for the command once the processor is stopped
+	processor := Processor{
+		Revision: Revision{
+			Version: 0,
+		},
+		Component: ProcessorComponent{
+			ParentGroupId: "root",
+			Name:          "execute_sleep",
+			Type:          "org.apache.nifi.processors.standard.ExecuteProcess",
+			Position: &Position{
+				X: 0,
+				Y: 0,
+			},
+			Config: &ProcessorConfig{
+				ExecutionNode:                    ExecutionNode_PRIMARY,
+				SchedulingStrategy:               SchedulingStrategy_TIMER_DRIVEN,
+				SchedulingPeriod:                 "1 min",
+				ConcurrentlySchedulableTaskCount: 1,
+				Properties: map[string]interface{}{
+					"Command":           "sleep",
+					"Command Arguments": "600",
+				},
+				AutoTerminatedRelationships: []string{
+					"success",
+				},
+			},
+		},
+	}
+	err := client.CreateProcessor(&processor)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, processor.Component.Id)
+
+	err = client.StartProcessor(&processor)
+	assert.Nil(t, err)
+	time.Sleep(5 * time.Second)
+
+	// Without terminate the stop times out while the thread is active
+	err = client.StopProcessorWithTimeout(&processor, 10*time.Second, false)
+	assert.NotNil(t, err)
+	current, err := client.GetProcessor(processor.Component.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, "STOPPED", current.Component.State)
+	assert.Equal(t, 1, current.ActiveThreadCount())
+
+	// With terminate the thread is terminated once the timeout expires
+	err = client.StopProcessorWithTimeout(current, 10*time.Second, true)
+	assert.Nil(t, err)
+	current, err = client.GetProcessor(processor.Component.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, current.ActiveThreadCount())
+	assert.Equal(t, 1, current.Status.AggregateSnapshot.TerminatedThreadCount)
+
+	err = client.DeleteProcessor(current)
+	assert.Nil(t, err)
+}
@@ -0,0 +1,120 @@
+package nifi
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+// Provenance section
+
+const (
+	ProvenanceSearchTerm_FLOWFILE_UUID = "FlowFileUUID"
+	ProvenanceSearchTerm_FILENAME      = "Filename"
+	ProvenanceSearchTerm_PROCESSOR_ID  = "ProcessorID"
+)
+
+// ProvenanceDateFormat is the format of the dates of provenance queries and events.
+const ProvenanceDateFormat = "01/02/2006 15:04:05 MST"
+
+// Search terms use the value/inverse form of NiFi 1.13 or later.
+type ProvenanceSearchTerm struct {
+	Value   string `json:"value"`
+	Inverse bool   `json:"inverse"`
+}
+
+type ProvenanceRequest struct {
+	MaxResults         int                             `json:"maxResults"`
+	Summarize          bool                            `json:"summarize"`
+	IncrementalResults bool                            `json:"incrementalResults"`
+	StartDate          string                          `json:"startDate,omitempty"`
+	EndDate            string                          `json:"endDate,omitempty"`
+	SearchTerms        map[string]ProvenanceSearchTerm `json:"searchTerms,omitempty"`
+}
+
+type ProvenanceEventAttribute struct {
+	Name          string  `json:"name"`
+	Value         *string `json:"value"`
+	PreviousValue *string `json:"previousValue"`
+}
+
+type ProvenanceEvent struct {
+	Id                     string                     `json:"id"`
+	EventId                int64                      `json:"eventId"`
+	EventTime              string                     `json:"eventTime"`
+	EventDuration          int64                      `json:"eventDuration"`
+	LineageDuration        int64                      `json:"lineageDuration"`
+	EventType              string                     `json:"eventType"`
+	FlowFileUuid           string                     `json:"flowFileUuid"`
+	FileSizeBytes          int64                      `json:"fileSizeBytes"`
+	ClusterNodeId          string                     `json:"clusterNodeId,omitempty"`
+	ClusterNodeAddress     string                     `json:"clusterNodeAddress,omitempty"`
+	GroupId                string                     `json:"groupId"`
+	ComponentId            string                     `json:"componentId"`
+	ComponentType          string                     `json:"componentType"`
+	ComponentName          string                     `json:"componentName"`
+	SourceSystemFlowFileId string                     `json:"sourceSystemFlowFileId,omitempty"`
+	AlternateIdentifierUri string                     `json:"alternateIdentifierUri,omitempty"`
+	Attributes             []ProvenanceEventAttribute `json:"attributes"`
+	ParentUuids            []string                   `json:"parentUuids"`
+	ChildUuids             []string                   `json:"childUuids"`
+	TransitUri             string                     `json:"transitUri,omitempty"`
+	Relationship           string                     `json:"relationship,omitempty"`
+	Details                string                     `json:"details,omitempty"`
+}
+
+type Provenance struct {
+	Provenance struct {
+		Id               string            `json:"id,omitempty"`
+		Finished         bool              `json:"finished,omitempty"`
+		PercentCompleted int               `json:"percentCompleted,omitempty"`
+		Request          ProvenanceRequest `json:"request"`
+		Results          struct {
+			ProvenanceEvents []ProvenanceEvent `json:"provenanceEvents"`
+			Total            string            `json:"total"`
+			TotalCount       int64             `json:"totalCount"`
+			Errors           []string          `json:"errors"`
+		} `json:"results,omitempty"`
+	} `json:"provenance"`
+}
+
+// QueryProvenance submits a provenance query, waits for its results and removes it.
+func (c *Client) QueryProvenance(request ProvenanceRequest, max_wait time.Duration) (*Provenance, error) {
+	url := fmt.Sprintf("%s/provenance",
+		baseurl(c.Config))
+	provenance := Provenance{}
+	provenance.Provenance.Request = request
+	_, err := c.JsonCall("POST", url, provenance, &provenance)
+	if nil != err {
+		return nil, err
+	}
+
+	queryId := provenance.Provenance.Id
+	url = fmt.Sprintf("%s/provenance/%s",
+		baseurl(c.Config), queryId)
+	defer func() {
+		_, err := c.JsonCall("DELETE", url, nil, nil)
+		if nil != err {
+			log.Printf("[WARN] Failed to delete provenance query %s: %s", queryId, err)
+		}
+	}()
+
+	if !provenance.Provenance.Finished {
+		query := fmt.Sprintf("%s?summarize=%t", url, request.Summarize)
+		err = c.WaitUtil(max_wait, func(c *Client) bool {
+			_, err := c.JsonCall("GET", query, nil, &provenance)
+			if nil != err {
+				return false
+			}
+			log.Printf("[INFO] Querying provenance %s, %d%% completed...", queryId, provenance.Provenance.PercentCompleted)
+			return provenance.Provenance.Finished
+		})
+		if nil != err {
+			return nil, fmt.Errorf("provenance query %s did not complete in %s", queryId, max_wait)
+		}
+	}
+	if len(provenance.Provenance.Results.Errors) > 0 {
+		return nil, fmt.Errorf("provenance query %s failed: %v", queryId, provenance.Provenance.Results.Errors)
+	}
+	return &provenance, nil
+}
@@ -0,0 +1,283 @@
+package nifi
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+// Remote Process Group section
+
+type RemoteProcessGroupState string
+
+const (
+	RemoteProcessGroupState_TRANSMITTING RemoteProcessGroupState = "TRANSMITTING"
+	RemoteProcessGroupState_STOPPED      RemoteProcessGroupState = "STOPPED"
+)
+
+type RemoteProcessGroupPortBatchSettings struct {
+	Count    int    `json:"count,omitempty"`
+	Size     string `json:"size,omitempty"`
+	Duration string `json:"duration,omitempty"`
+}
+
+type RemoteProcessGroupPortComponent struct {
+	Id                               string                               `json:"id,omitempty"`
+	TargetId                         string                               `json:"targetId,omitempty"`
+	GroupId                          string                               `json:"groupId,omitempty"`
+	Name                             string                               `json:"name,omitempty"`
+	ConcurrentlySchedulableTaskCount int                                  `json:"concurrentlySchedulableTaskCount,omitempty"`
+	UseCompression                   bool                                 `json:"useCompression"`
+	Transmitting                     *bool                                `json:"transmitting,omitempty"`
+	Exists                           bool                                 `json:"exists,omitempty"`
+	Connected                        bool                                 `json:"connected,omitempty"`
+	BatchSettings                    *RemoteProcessGroupPortBatchSettings `json:"batchSettings,omitempty"`
+}
+
+type RemoteProcessGroupPort struct {
+	Revision               Revision                        `json:"revision"`
+	RemoteProcessGroupPort RemoteProcessGroupPortComponent `json:"remoteProcessGroupPort"`
+}
+
+type RemoteProcessGroupContents struct {
+	InputPorts  []RemoteProcessGroupPortComponent `json:"inputPorts"`
+	OutputPorts []RemoteProcessGroupPortComponent `json:"outputPorts"`
+}
+
+type RemoteProcessGroupComponent struct {
+	Id                    string                      `json:"id,omitempty"`
+	ParentGroupId         string                      `json:"parentGroupId"`
+	Name                  string                      `json:"name"`
+	Comments              string                      `json:"comments"`
+	Position              Position                    `json:"position"`
+	TargetUris            string                      `json:"targetUris"`
+	TransportProtocol     string                      `json:"transportProtocol"`
+	CommunicationsTimeout string                      `json:"communicationsTimeout,omitempty"`
+	YieldDuration         string                      `json:"yieldDuration,omitempty"`
+	LocalNetworkInterface string                      `json:"localNetworkInterface"`
+	ProxyHost             string                      `json:"proxyHost"`
+	ProxyPort             *int                        `json:"proxyPort,omitempty"`
+	ProxyUser             string                      `json:"proxyUser"`
+	ProxyPassword         string                      `json:"proxyPassword,omitempty"`
+	Transmitting          *bool                       `json:"transmitting,omitempty"`
+	Contents              *RemoteProcessGroupContents `json:"contents,omitempty"`
+	// Time of the last refresh of the contents, empty until the ports of the target instance are first listed
+	FlowRefreshed       string   `json:"flowRefreshed,omitempty"`
+	AuthorizationIssues []string `json:"authorizationIssues,omitempty"`
+}
+
+type RemoteProcessGroup struct {
+	Revision  Revision                    `json:"revision"`
+	Component RemoteProcessGroupComponent `json:"component"`
+}
+
+type RemoteProcessGroupRunStatus struct {
+	Revision Revision                `json:"revision"`
+	State    RemoteProcessGroupState `json:"state"`
+}
+
+func (c *Client) CreateRemoteProcessGroup(processGroup *RemoteProcessGroup) error {
+	url := fmt.Sprintf("%s/process-groups/%s/remote-process-groups",
+		baseurl(c.Config), processGroup.Component.ParentGroupId)
+	_, err := c.JsonCall("POST", url, processGroup, processGroup)
+	return err
+}
+
+func (c *Client) GetRemoteProcessGroup(processGroupId string) (*RemoteProcessGroup, error) {
+	url := fmt.Sprintf("%s/remote-process-groups/%s",
+		baseurl(c.Config), processGroupId)
+	processGroup := RemoteProcessGroup{}
+	code, err := c.JsonCall("GET", url, nil, &processGroup)
+	if code == 404 {
+		return nil, fmt.Errorf("not_found")
+	}
+	if nil != err {
+		return nil, err
+	}
+	return &processGroup, nil
+}
+
+func (c *Client) UpdateRemoteProcessGroup(processGroup *RemoteProcessGroup) error {
+	url := fmt.Sprintf("%s/remote-process-groups/%s",
+		baseurl(c.Config), processGroup.Component.Id)
+	_, err := c.JsonCall("PUT", url, processGroup, processGroup)
+	return err
+}
+
+func (c *Client) DeleteRemoteProcessGroup(processGroup *RemoteProcessGroup) error {
+	url := fmt.Sprintf("%s/remote-process-groups/%s?version=%d",
+		baseurl(c.Config), processGroup.Component.Id, processGroup.Revision.Version)
+	_, err := c.JsonCall("DELETE", url, nil, nil)
+	return err
+}
+
+func (c *Client) SetRemoteProcessGroupState(processGroup *RemoteProcessGroup, state RemoteProcessGroupState) error {
+	url := fmt.Sprintf("%s/remote-process-groups/%s/run-status",
+		baseurl(c.Config), processGroup.Component.Id)
+	runStatus := RemoteProcessGroupRunStatus{
+		Revision: Revision{
+			Version: processGroup.Revision.Version,
+		},
+		State: state,
+	}
+	_, err := c.JsonCall("PUT", url, runStatus, processGroup)
+	return err
+}
+
+func (c *Client) StartRemoteProcessGroupTransmission(processGroup *RemoteProcessGroup) error {
+	return c.SetRemoteProcessGroupState(processGroup, RemoteProcessGroupState_TRANSMITTING)
+}
+
+func (c *Client) StopRemoteProcessGroupTransmission(processGroup *RemoteProcessGroup) error {
+	return c.SetRemoteProcessGroupState(processGroup, RemoteProcessGroupState_STOPPED)
+}
+
+// UpdateRemoteProcessGroupPort changes the configuration of a port discovered on the target instance.
+// Remote ports share the revision of their remote process group.
+func (c *Client) UpdateRemoteProcessGroupPort(processGroup *RemoteProcessGroup, port *RemoteProcessGroupPortComponent, portType PortType) error {
+	url := ""
+	switch portType {
+	case PortType_INPUT_PORT:
+		url = fmt.Sprintf("%s/remote-process-groups/%s/input-ports/%s",
+			baseurl(c.Config), processGroup.Component.Id, port.Id)
+	case PortType_OUTPUT_PORT:
+		url = fmt.Sprintf("%s/remote-process-groups/%s/output-ports/%s",
+			baseurl(c.Config), processGroup.Component.Id, port.Id)
+	default:
+		return fmt.Errorf("invalid port type : %s", string(portType))
+	}
+	port.GroupId = processGroup.Component.Id
+	portUpdate := RemoteProcessGroupPort{
+		Revision: Revision{
+			Version: processGroup.Revision.Version,
+		},
+		RemoteProcessGroupPort: *port,
+	}
+	_, err := c.JsonCall("PUT", url, portUpdate, &portUpdate)
+	if nil != err {
+		return err
+	}
+	processGroup.Revision = portUpdate.Revision
+	*port = portUpdate.RemoteProcessGroupPort
+	return nil
+}
+
+func (processGroup *RemoteProcessGroup) findPort(portType PortType, match func(port *RemoteProcessGroupPortComponent) bool) *RemoteProcessGroupPortComponent {
+	contents := processGroup.Component.Contents
+	if contents == nil {
+		return nil
+	}
+	ports := contents.InputPorts
+	if portType == PortType_OUTPUT_PORT {
+		ports = contents.OutputPorts
+	}
+	for i := range ports {
+		if match(&ports[i]) {
+			return &ports[i]
+		}
+	}
+	return nil
+}
+
+// FindPort looks up a port discovered on the target instance by its name.
+func (processGroup *RemoteProcessGroup) FindPort(name string, portType PortType) *RemoteProcessGroupPortComponent {
+	return processGroup.findPort(portType, func(port *RemoteProcessGroupPortComponent) bool {
+		return port.Name == name
+	})
+}
+
+// FindPortById looks up a port of the remote process group by the id used in connections.
+func (processGroup *RemoteProcessGroup) FindPortById(id string, portType PortType) *RemoteProcessGroupPortComponent {
+	return processGroup.findPort(portType, func(port *RemoteProcessGroupPortComponent) bool {
+		return port.Id == id
+	})
+}
+
+func (c *Client) SetRemoteProcessGroupPortState(processGroupId string, portId string, portType PortType, state RemoteProcessGroupState) error {
+	processGroup, err := c.GetRemoteProcessGroup(processGroupId)
+	if nil != err {
+		return err
+	}
+	url := ""
+	switch portType {
+	case PortType_INPUT_PORT:
+		url = fmt.Sprintf("%s/remote-process-groups/%s/input-ports/%s/run-status",
+			baseurl(c.Config), processGroupId, portId)
+	case PortType_OUTPUT_PORT:
+		url = fmt.Sprintf("%s/remote-process-groups/%s/output-ports/%s/run-status",
+			baseurl(c.Config), processGroupId, portId)
+	default:
+		return fmt.Errorf("invalid port type : %s", string(portType))
+	}
+	runStatus := RemoteProcessGroupRunStatus{
+		Revision: Revision{
+			Version: processGroup.Revision.Version,
+		},
+		State: state,
+	}
+	_, err = c.JsonCall("PUT", url, runStatus, nil)
+	return err
+}
+
+// WaitRemoteProcessGroupPort checks that the remote process group lists the port. The ports of the target instance
+// are listed asynchronously after creation, the remote process group is only polled until its contents are first
+// refreshed. An unknown port is reported right away once they are.
+func (c *Client) WaitRemoteProcessGroupPort(processGroupId string, portId string, portType PortType, max_wait time.Duration) error {
+	processGroup, err := c.GetRemoteProcessGroup(processGroupId)
+	if err != nil {
+		return fmt.Errorf("error retrieving Remote Process Group %s: %s", processGroupId, err)
+	}
+	if processGroup.Component.FlowRefreshed == "" {
+		err = c.WaitUtil(max_wait, func(c *Client) bool {
+			current, err := c.GetRemoteProcessGroup(processGroupId)
+			if err != nil {
+				return false
+			}
+			processGroup = current
+			log.Printf("[INFO] Waiting for Remote Process Group %s to list the ports of %s", processGroupId, processGroup.Component.TargetUris)
+			return processGroup.Component.FlowRefreshed != ""
+		})
+		if err != nil {
+			return fmt.Errorf("remote Process Group %s did not list the ports of %s in %s %v",
+				processGroupId, processGroup.Component.TargetUris, max_wait, processGroup.Component.AuthorizationIssues)
+		}
+	}
+
+	port := processGroup.FindPortById(portId, portType)
+	if port == nil {
+		return fmt.Errorf("remote %s %s does not exist in Remote Process Group %s", portType, portId, processGroupId)
+	}
+	if !port.Exists {
+		return fmt.Errorf("remote %s %s (%s) no longer exists on %s", portType, portId, port.Name, processGroup.Component.TargetUris)
+	}
+	return nil
+}
+
+// WaitRemoteProcessGroupPorts waits until the remote process group has discovered every named port on the target instance.
+func (c *Client) WaitRemoteProcessGroupPorts(processGroupId string, inputPorts []string, outputPorts []string, max_wait time.Duration) (*RemoteProcessGroup, error) {
+	var processGroup *RemoteProcessGroup
+	err := c.WaitUtil(max_wait, func(c *Client) bool {
+		var err error
+		processGroup, err = c.GetRemoteProcessGroup(processGroupId)
+		if err != nil {
+			return false
+		}
+		for _, name := range inputPorts {
+			if processGroup.FindPort(name, PortType_INPUT_PORT) == nil {
+				log.Printf("[INFO] Waiting for Remote Process Group %s to discover input port %s", processGroupId, name)
+				return false
+			}
+		}
+		for _, name := range outputPorts {
+			if processGroup.FindPort(name, PortType_OUTPUT_PORT) == nil {
+				log.Printf("[INFO] Waiting for Remote Process Group %s to discover output port %s", processGroupId, name)
+				return false
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("remote ports %v %v were not discovered by Remote Process Group %s", inputPorts, outputPorts, processGroupId)
+	}
+	return processGroup, nil
+}
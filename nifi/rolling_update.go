@@ -0,0 +1,177 @@
+package nifi
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+// Rolling update section
+
+// rollingUpdatePlan lists the components of a process group affected by a change in dependency order,
+// sources first, with the connections feeding each of them.
+type rollingUpdatePlan struct {
+	order    []ConnectionHand
+	changed  map[string]bool
+	incoming map[string][]Connection
+}
+
+// newRollingUpdatePlan computes the plan from the connections of the group. The changed components and every
+// component upstream of them are affected, every connected component is affected when nothing is changed.
+// Components in a cycle are ordered by their first appearance in the connections.
+func newRollingUpdatePlan(connections []Connection, changed []ConnectionHand) *rollingUpdatePlan {
+	plan := &rollingUpdatePlan{
+		changed:  map[string]bool{},
+		incoming: map[string][]Connection{},
+	}
+
+	hands := map[string]ConnectionHand{}
+	appearance := []string{}
+	addHand := func(hand ConnectionHand) {
+		if _, ok := hands[hand.Id]; !ok {
+			hands[hand.Id] = hand
+			appearance = append(appearance, hand.Id)
+		}
+	}
+	for _, hand := range changed {
+		addHand(hand)
+		plan.changed[hand.Id] = true
+	}
+	upstream := map[string][]string{}
+	for _, connection := range connections {
+		source := connection.Component.Source
+		destination := connection.Component.Destination
+		addHand(source)
+		addHand(destination)
+		if source.Id != destination.Id {
+			upstream[destination.Id] = append(upstream[destination.Id], source.Id)
+		}
+	}
+
+	affected := map[string]bool{}
+	if len(changed) == 0 {
+		for id := range hands {
+			affected[id] = true
+		}
+	} else {
+		pending := []string{}
+		for _, hand := range changed {
+			pending = append(pending, hand.Id)
+		}
+		for len(pending) > 0 {
+			id := pending[0]
+			pending = pending[1:]
+			if affected[id] {
+				continue
+			}
+			affected[id] = true
+			pending = append(pending, upstream[id]...)
+		}
+	}
+
+	inDegree := map[string]int{}
+	downstream := map[string][]string{}
+	for _, connection := range connections {
+		source := connection.Component.Source.Id
+		destination := connection.Component.Destination.Id
+		if source == destination || !affected[source] || !affected[destination] {
+			continue
+		}
+		inDegree[destination]++
+		downstream[source] = append(downstream[source], destination)
+		plan.incoming[destination] = append(plan.incoming[destination], connection)
+	}
+
+	ordered := map[string]bool{}
+	for len(ordered) < len(affected) {
+		progress := false
+		for _, id := range appearance {
+			if !affected[id] || ordered[id] || inDegree[id] > 0 {
+				continue
+			}
+			ordered[id] = true
+			plan.order = append(plan.order, hands[id])
+			for _, next := range downstream[id] {
+				inDegree[next]--
+			}
+			progress = true
+		}
+		if progress {
+			continue
+		}
+		// Break a cycle at its first component
+		for _, id := range appearance {
+			if affected[id] && !ordered[id] {
+				inDegree[id] = 0
+				break
+			}
+		}
+	}
+	return plan
+}
+
+// RollingUpdate applies a change to components of a process group without losing or misrouting FlowFiles.
+// Components upstream of the changed ones are stopped sources first, each one once the queues coming from the
+// already stopped components are drained. The change is applied once everything is stopped, then the components
+// are started again in reverse order. Changed components are always started, the others only when they were running.
+// Every connected component of the group is affected when changed is empty.
+func (c *Client) RollingUpdate(processGroupId string, changed []ConnectionHand, drain_timeout time.Duration, apply func() error) error {
+	connections, err := c.GetProcessGroupConnections(processGroupId)
+	if nil != err {
+		return fmt.Errorf("error retrieving Process Group connections: %s", err)
+	}
+	plan := newRollingUpdatePlan(connections.Connections, changed)
+
+	stopped := []ConnectionHand{}
+	restart := func() {
+		for i := len(stopped) - 1; i >= 0; i-- {
+			err := c.StartConnectionHand(&stopped[i])
+			if nil != err {
+				log.Printf("[WARN] Rolling update of %s: failed to start %s %s: %s", processGroupId, stopped[i].Type, stopped[i].Id, err)
+			}
+		}
+	}
+
+	done := map[string]bool{}
+	for i := range plan.order {
+		hand := plan.order[i]
+
+		// Queues from components still running in a cycle cannot be drained
+		drained := []string{}
+		for _, connection := range plan.incoming[hand.Id] {
+			if done[connection.Component.Source.Id] {
+				drained = append(drained, connection.Component.Id)
+			}
+		}
+		if len(drained) > 0 {
+			log.Printf("[INFO] Rolling update of %s: draining queues to %s %s", processGroupId, hand.Type, hand.Id)
+			err = c.WaitConnectionsEmpty(drained, drain_timeout)
+			if nil != err {
+				restart()
+				return err
+			}
+		}
+		done[hand.Id] = true
+
+		running, err := c.ConnectionHandRunning(&hand)
+		if nil != err {
+			restart()
+			return err
+		}
+		if running {
+			log.Printf("[INFO] Rolling update of %s: stopping %s %s", processGroupId, hand.Type, hand.Id)
+			err = c.StopConnectionHand(&hand)
+			if nil != err {
+				restart()
+				return err
+			}
+		}
+		if running || plan.changed[hand.Id] {
+			stopped = append(stopped, hand)
+		}
+	}
+
+	err = apply()
+	restart()
+	return err
+}
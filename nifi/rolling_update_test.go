@@ -0,0 +1,61 @@
+package nifi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func rollingUpdateConnection(id string, source string, destination string) Connection {
+	return Connection{
+		Component: ConnectionComponent{
+			Id:          id,
+			Source:      ConnectionHand{Id: source, Type: ConnectionHand_Type_PROCESSOR},
+			Destination: ConnectionHand{Id: destination, Type: ConnectionHand_Type_PROCESSOR},
+		},
+	}
+}
+
+func rollingUpdateOrder(plan *rollingUpdatePlan) []string {
+	order := []string{}
+	for _, hand := range plan.order {
+		order = append(order, hand.Id)
+	}
+	return order
+}
+
+func TestRollingUpdatePlan(t *testing.T) {
+	// generate -> split -> transform -> put, split -> log, retry loop on transform
+	connections := []Connection{
+		rollingUpdateConnection("c3", "transform", "put"),
+		rollingUpdateConnection("c2", "split", "transform"),
+		rollingUpdateConnection("c1", "generate", "split"),
+		rollingUpdateConnection("c4", "split", "log"),
+		rollingUpdateConnection("c5", "transform", "transform"),
+	}
+
+	plan := newRollingUpdatePlan(connections, nil)
+	assert.Equal(t, []string{"generate", "split", "log", "transform", "put"}, rollingUpdateOrder(plan))
+
+	changed := []ConnectionHand{{Id: "transform", Type: ConnectionHand_Type_PROCESSOR}}
+	plan = newRollingUpdatePlan(connections, changed)
+	assert.Equal(t, []string{"generate", "split", "transform"}, rollingUpdateOrder(plan))
+	assert.True(t, plan.changed["transform"])
+	assert.Len(t, plan.incoming["transform"], 1)
+	assert.Equal(t, "c2", plan.incoming["transform"][0].Component.Id)
+
+	unconnected := []ConnectionHand{{Id: "wait", Type: ConnectionHand_Type_PROCESSOR}}
+	plan = newRollingUpdatePlan(connections, unconnected)
+	assert.Equal(t, []string{"wait"}, rollingUpdateOrder(plan))
+}
+
+func TestRollingUpdatePlanCycle(t *testing.T) {
+	connections := []Connection{
+		rollingUpdateConnection("c1", "source", "a"),
+		rollingUpdateConnection("c2", "a", "b"),
+		rollingUpdateConnection("c3", "b", "a"),
+	}
+
+	plan := newRollingUpdatePlan(connections, nil)
+	assert.Equal(t, []string{"source", "a", "b"}, rollingUpdateOrder(plan))
+}
@@ -0,0 +1,106 @@
+package nifi
+
+import "fmt"
+
+// Snippet section
+
+type SnippetComponentType string
+
+const (
+	SnippetComponentType_PROCESSOR     SnippetComponentType = "PROCESSOR"
+	SnippetComponentType_INPUT_PORT    SnippetComponentType = "INPUT_PORT"
+	SnippetComponentType_OUTPUT_PORT   SnippetComponentType = "OUTPUT_PORT"
+	SnippetComponentType_FUNNEL        SnippetComponentType = "FUNNEL"
+	SnippetComponentType_PROCESS_GROUP SnippetComponentType = "PROCESS_GROUP"
+	SnippetComponentType_LABEL         SnippetComponentType = "LABEL"
+)
+
+type SnippetComponent struct {
+	Id                  string              `json:"id,omitempty"`
+	ParentGroupId       string              `json:"parentGroupId,omitempty"`
+	Processors          map[string]Revision `json:"processors,omitempty"`
+	InputPorts          map[string]Revision `json:"inputPorts,omitempty"`
+	OutputPorts         map[string]Revision `json:"outputPorts,omitempty"`
+	Funnels             map[string]Revision `json:"funnels,omitempty"`
+	ProcessGroups       map[string]Revision `json:"processGroups,omitempty"`
+	RemoteProcessGroups map[string]Revision `json:"remoteProcessGroups,omitempty"`
+	Labels              map[string]Revision `json:"labels,omitempty"`
+	Connections         map[string]Revision `json:"connections,omitempty"`
+}
+
+type Snippet struct {
+	Snippet SnippetComponent `json:"snippet"`
+}
+
+func (c *Client) CreateSnippet(snippet *Snippet) error {
+	url := fmt.Sprintf("%s/snippets",
+		baseurl(c.Config))
+	_, err := c.JsonCall("POST", url, snippet, snippet)
+	return err
+}
+
+func (c *Client) MoveSnippet(snippet *Snippet, parentGroupId string) error {
+	url := fmt.Sprintf("%s/snippets/%s",
+		baseurl(c.Config), snippet.Snippet.Id)
+	move := Snippet{
+		Snippet: SnippetComponent{
+			Id:            snippet.Snippet.Id,
+			ParentGroupId: parentGroupId,
+		},
+	}
+	_, err := c.JsonCall("PUT", url, move, snippet)
+	return err
+}
+
+// MoveComponent moves a single component from its current process group into another one.
+// NiFi refuses the move when the component still has connections in the source group.
+func (c *Client) MoveComponent(componentType SnippetComponentType, componentId string, revision Revision, fromGroupId string, toGroupId string) error {
+	revisions := map[string]Revision{
+		componentId: revision,
+	}
+	snippet := Snippet{
+		Snippet: SnippetComponent{
+			ParentGroupId: fromGroupId,
+		},
+	}
+	switch componentType {
+	case SnippetComponentType_PROCESSOR:
+		snippet.Snippet.Processors = revisions
+	case SnippetComponentType_INPUT_PORT:
+		snippet.Snippet.InputPorts = revisions
+	case SnippetComponentType_OUTPUT_PORT:
+		snippet.Snippet.OutputPorts = revisions
+	case SnippetComponentType_FUNNEL:
+		snippet.Snippet.Funnels = revisions
+	case SnippetComponentType_PROCESS_GROUP:
+		snippet.Snippet.ProcessGroups = revisions
+	case SnippetComponentType_LABEL:
+		snippet.Snippet.Labels = revisions
+	default:
+		return fmt.Errorf("invalid snippet component type : %s", string(componentType))
+	}
+
+	err := c.CreateSnippet(&snippet)
+	if nil != err {
+		return err
+	}
+	return c.MoveSnippet(&snippet, toGroupId)
+}
+
+// ComponentConnections returns the connections of the given process group that start or end at the component.
+func (c *Client) ComponentConnections(processGroupId string, componentId string) ([]Connection, error) {
+	groupConnections, err := c.GetProcessGroupConnections(processGroupId)
+	if nil != err {
+		return nil, err
+	}
+	connections := []Connection{}
+	for _, connection := range groupConnections.Connections {
+		source := connection.Component.Source
+		destination := connection.Component.Destination
+		if source.Id == componentId || destination.Id == componentId ||
+			source.GroupId == componentId || destination.GroupId == componentId {
+			connections = append(connections, connection)
+		}
+	}
+	return connections, nil
+}
@@ -0,0 +1,63 @@
+package nifi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientMoveComponent(t *testing.T) {
+	client := setup()
+
+	processGroup := ProcessGroup{
+		Revision: Revision{
+			Version: 0,
+		},
+		Component: ProcessGroupComponent{
+			ParentGroupId: "root",
+			Name:          "move_target",
+			Position: Position{
+				X: 0,
+				Y: 0,
+			},
+		},
+	}
+	err := client.CreateProcessGroup(&processGroup)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, processGroup.Component.Id)
+
+	funnel := Funnel{
+		Revision: Revision{
+			Version: 0,
+		},
+		Component: FunnelComponent{
+			ParentGroupId: "root",
+			Position: Position{
+				X: 0,
+				Y: 0,
+			},
+		},
+	}
+	err = client.CreateFunnel(&funnel)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, funnel.Component.Id)
+
+	connections, err := client.ComponentConnections("root", funnel.Component.Id)
+	assert.Nil(t, err)
+	assert.Empty(t, connections)
+
+	err = client.MoveComponent(SnippetComponentType_FUNNEL, funnel.Component.Id, funnel.Revision, "root", processGroup.Component.Id)
+	assert.Nil(t, err)
+
+	movedFunnel, err := client.GetFunnel(funnel.Component.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, processGroup.Component.Id, movedFunnel.Component.ParentGroupId)
+
+	err = client.DeleteFunnel(movedFunnel)
+	assert.Nil(t, err)
+
+	processGroup2, err := client.GetProcessGroup(processGroup.Component.Id)
+	assert.Nil(t, err)
+	err = client.DeleteProcessGroup(processGroup2)
+	assert.Nil(t, err)
+}
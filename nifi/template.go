@@ -0,0 +1,36 @@
+package nifi
+
+import "fmt"
+
+type TemplateComponent struct {
+	Id      string `json:"id"`
+	GroupId string `json:"groupId"`
+	Name    string `json:"name"`
+}
+
+type Template struct {
+	Id       string            `json:"id"`
+	Template TemplateComponent `json:"template"`
+}
+
+type Templates struct {
+	Templates []Template `json:"templates"`
+}
+
+func (c *Client) GetTemplates() (*Templates, error) {
+	url := fmt.Sprintf("%s/flow/templates",
+		baseurl(c.Config))
+	templates := Templates{}
+	_, err := c.JsonCall("GET", url, nil, &templates)
+	if nil != err {
+		return nil, err
+	}
+	return &templates, nil
+}
+
+func (c *Client) DeleteTemplate(templateId string) error {
+	url := fmt.Sprintf("%s/templates/%s",
+		baseurl(c.Config), templateId)
+	_, err := c.JsonCall("DELETE", url, nil, nil)
+	return err
+}
@@ -0,0 +1,19 @@
+package nifi
+
+// Validation section
+
+type ValidationStatus string
+
+const (
+	ValidationStatus_VALID      ValidationStatus = "VALID"
+	ValidationStatus_INVALID    ValidationStatus = "INVALID"
+	ValidationStatus_VALIDATING ValidationStatus = "VALIDATING"
+)
+
+// Ports only report validation errors, their status is derived from them.
+func (port *Port) ValidationStatus() ValidationStatus {
+	if len(port.Component.ValidationErrors) > 0 {
+		return ValidationStatus_INVALID
+	}
+	return ValidationStatus_VALID
+}
@@ -0,0 +1,93 @@
+package nifi
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+// Configuration verification section
+
+type ConfigVerificationOutcome string
+
+const (
+	ConfigVerificationOutcome_SUCCESSFUL ConfigVerificationOutcome = "SUCCESSFUL"
+	ConfigVerificationOutcome_FAILED     ConfigVerificationOutcome = "FAILED"
+	ConfigVerificationOutcome_SKIPPED    ConfigVerificationOutcome = "SKIPPED"
+)
+
+type ConfigVerificationResult struct {
+	Outcome              ConfigVerificationOutcome `json:"outcome"`
+	VerificationStepName string                    `json:"verificationStepName"`
+	Explanation          string                    `json:"explanation"`
+}
+
+type VerifyConfigRequestComponent struct {
+	RequestId        string                     `json:"requestId,omitempty"`
+	ComponentId      string                     `json:"componentId"`
+	Properties       map[string]interface{}     `json:"properties"`
+	Attributes       map[string]string          `json:"attributes,omitempty"`
+	Complete         bool                       `json:"complete,omitempty"`
+	PercentCompleted int                        `json:"percentCompleted,omitempty"`
+	FailureReason    string                     `json:"failureReason,omitempty"`
+	Results          []ConfigVerificationResult `json:"results,omitempty"`
+}
+
+type VerifyConfigRequest struct {
+	Request VerifyConfigRequestComponent `json:"request"`
+}
+
+// VerifyComponentConfig runs an asynchronous configuration verification of a stopped processor, disabled controller
+// service or stopped reporting task with the given properties. FlowFile attributes are used to evaluate expressions.
+func (c *Client) VerifyComponentConfig(kind ComponentKind, componentId string, properties map[string]interface{},
+	attributes map[string]string, max_wait time.Duration) ([]ConfigVerificationResult, error) {
+	path := ""
+	switch kind {
+	case ComponentKind_PROCESSOR:
+		path = "processors"
+	case ComponentKind_CONTROLLER_SERVICE:
+		path = "controller-services"
+	case ComponentKind_REPORTING_TASK:
+		path = "reporting-tasks"
+	default:
+		return nil, fmt.Errorf("invalid component kind : %s", string(kind))
+	}
+	url := fmt.Sprintf("%s/%s/%s/config/verification-requests",
+		baseurl(c.Config), path, componentId)
+	request := VerifyConfigRequest{
+		Request: VerifyConfigRequestComponent{
+			ComponentId: componentId,
+			Properties:  properties,
+			Attributes:  attributes,
+		},
+	}
+	_, err := c.JsonCall("POST", url, request, &request)
+	if nil != err {
+		return nil, err
+	}
+	requestUrl := fmt.Sprintf("%s/%s", url, request.Request.RequestId)
+	defer func() {
+		_, err := c.JsonCall("DELETE", requestUrl, nil, nil)
+		if nil != err {
+			log.Printf("[WARN] Failed to delete verification request %s: %s", request.Request.RequestId, err)
+		}
+	}()
+
+	if !request.Request.Complete {
+		err = c.WaitUtil(max_wait, func(c *Client) bool {
+			_, err := c.JsonCall("GET", requestUrl, nil, &request)
+			if nil != err {
+				return false
+			}
+			log.Printf("[INFO] Verifying configuration of %s: %d%%", componentId, request.Request.PercentCompleted)
+			return request.Request.Complete
+		})
+		if nil != err {
+			return nil, fmt.Errorf("configuration verification of %s did not complete in %s", componentId, max_wait)
+		}
+	}
+	if request.Request.FailureReason != "" {
+		return nil, fmt.Errorf("configuration verification of %s failed: %s", componentId, request.Request.FailureReason)
+	}
+	return request.Request.Results, nil
+}
@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	nifi "github.com/glympse/terraform-provider-nifi/nifi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Components often report their problems as bulletins once started. When bulletin_warning_delay is set,
+// the bulletins emitted by the component after it is applied are returned as warnings.
+
+func ComponentBulletinApply(apply func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		client := meta.(*nifi.Client)
+		delay := client.Config.BulletinWarningDelay
+		if delay == 0 {
+			return apply(ctx, d, meta)
+		}
+
+		// Bulletins emitted before an update are not reported again
+		after := int64(0)
+		if d.Id() != "" {
+			last, err := client.LastBulletinId(nifi.BulletinFilter{SourceId: d.Id()})
+			if err != nil {
+				log.Printf("[WARN] Unable to retrieve bulletins of %s: %s", d.Id(), err)
+				return apply(ctx, d, meta)
+			}
+			after = last
+		}
+
+		diags := apply(ctx, d, meta)
+		if diags.HasError() || d.Id() == "" {
+			return diags
+		}
+
+		select {
+		case <-ctx.Done():
+			return diags
+		case <-time.After(delay):
+		}
+		board, err := client.GetBulletinBoard(nifi.BulletinFilter{SourceId: d.Id(), After: after})
+		if err != nil {
+			log.Printf("[WARN] Unable to retrieve bulletins of %s: %s", d.Id(), err)
+			return diags
+		}
+		return append(diags, ComponentBulletinDiagnostics(board)...)
+	}
+}
+
+func ComponentBulletinDiagnostics(board *nifi.BulletinBoard) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, v := range board.BulletinBoard.Bulletins {
+		bulletin := v.Bulletin
+		if bulletin == nil {
+			continue
+		}
+		if bulletin.Level != nifi.BulletinLevel_ERROR && bulletin.Level != nifi.BulletinLevel_WARNING {
+			continue
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("%s reported a %s bulletin", bulletin.SourceName, bulletin.Level),
+			Detail:   bulletin.Message,
+		})
+	}
+	return diags
+}
@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	nifi "github.com/glympse/terraform-provider-nifi/nifi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Parent group changes are performed as a snippet move. NiFi only allows moving components that
+// have no connections in their current group, nor ports connected from the enclosing group, so
+// the plan fails naming those connections instead of moving the component.
+
+func ComponentMoveCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("component.0.parent_group_id") {
+		return nil
+	}
+	client, ok := meta.(*nifi.Client)
+	if !ok || client == nil {
+		return nil
+	}
+
+	oldGroupId, newGroupId := d.GetChange("component.0.parent_group_id")
+	connections, err := ComponentMoveConnections(client, d, oldGroupId.(string))
+	if err != nil {
+		return err
+	}
+	if len(connections) == 0 {
+		return nil
+	}
+
+	connectionIds := []string{}
+	for _, connection := range connections {
+		connectionIds = append(connectionIds, connection.Component.Id)
+	}
+	return fmt.Errorf("component %s cannot be moved from Process Group %s to %s while it is connected by %s, "+
+		"remove those connections first", d.Id(), oldGroupId, newGroupId, strings.Join(connectionIds, ", "))
+}
+
+// Ports are also connected from the group enclosing their parent group
+func ComponentMoveConnections(client *nifi.Client, d *schema.ResourceDiff, groupId string) ([]nifi.Connection, error) {
+	connections, err := client.ComponentConnections(groupId, d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving Process Group connections: %s", groupId)
+	}
+
+	portType := d.Get("component.0.type")
+	if portType != string(nifi.PortType_INPUT_PORT) && portType != string(nifi.PortType_OUTPUT_PORT) {
+		return connections, nil
+	}
+	group, err := client.GetProcessGroup(groupId)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving Process Group: %s", groupId)
+	}
+	parentGroupId := group.Component.ParentGroupId
+	if parentGroupId == "" {
+		return connections, nil
+	}
+	parentConnections, err := client.ComponentConnections(parentGroupId, d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving Process Group connections: %s", parentGroupId)
+	}
+	return append(connections, parentConnections...), nil
+}
+
+func ComponentMove(client *nifi.Client, d *schema.ResourceData, componentType nifi.SnippetComponentType, revision nifi.Revision) (bool, error) {
+	fromGroupId := d.Get("parent_group_id").(string)
+	toGroupId := d.Get("component.0.parent_group_id").(string)
+	if fromGroupId == "" || fromGroupId == toGroupId {
+		return false, nil
+	}
+
+	log.Printf("[INFO] Moving %s %s from Process Group %s to %s", componentType, d.Id(), fromGroupId, toGroupId)
+	err := client.MoveComponent(componentType, d.Id(), revision, fromGroupId, toGroupId)
+	if err != nil {
+		return false, err
+	}
+	d.Set("parent_group_id", toGroupId)
+	return true, nil
+}
@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"fmt"
+	"time"
+
+	nifi "github.com/glympse/terraform-provider-nifi/nifi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Updating a component in place lets the FlowFiles queued around it pile up or be routed while it changes.
+// With a rolling_update block the components upstream are stopped sources first and their queues drained
+// before the change is applied, then everything is started again in reverse order.
+
+const ComponentRollingUpdateDefaultDrainTimeout = "5m"
+
+func SchemaRollingUpdate() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"drain_timeout": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      ComponentRollingUpdateDefaultDrainTimeout,
+					ValidateFunc: ValidatePositiveDuration,
+				},
+			},
+		},
+	}
+}
+
+func ValidatePositiveDuration(i interface{}, k string) ([]string, []error) {
+	duration, err := time.ParseDuration(i.(string))
+	if err != nil {
+		return nil, []error{fmt.Errorf("expected %s to be a duration, got %s", k, i)}
+	}
+	if duration <= 0 {
+		return nil, []error{fmt.Errorf("expected %s to be positive, got %s", k, i)}
+	}
+	return nil, nil
+}
+
+func ComponentRollingUpdateEnabled(d *schema.ResourceData) bool {
+	return len(d.Get("rolling_update").([]interface{})) > 0
+}
+
+// ComponentRollingUpdate runs apply as a rolling update of the changed components of the group, or every connected
+// component when changed is empty. The caller must not start the changed components when the update is rolling.
+func ComponentRollingUpdate(client *nifi.Client, d *schema.ResourceData, processGroupId string, changed []nifi.ConnectionHand, apply func() error) error {
+	if !ComponentRollingUpdateEnabled(d) {
+		return apply()
+	}
+	drainTimeout, err := time.ParseDuration(d.Get("rolling_update.0.drain_timeout").(string))
+	if err != nil {
+		return fmt.Errorf("invalid rolling_update.0.drain_timeout: %s", err)
+	}
+	return client.RollingUpdate(processGroupId, changed, drainTimeout, apply)
+}
@@ -0,0 +1,277 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	nifi "github.com/glympse/terraform-provider-nifi/nifi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Properties and relationships are checked at plan time against the descriptors of the component type.
+// Definitions are only available from NiFi 1.20, validation is skipped when they cannot be retrieved.
+
+// Unknown properties are accepted as dynamic properties by types supporting them, unless allow_dynamic_properties
+// is unset to check property names strictly.
+func SchemaAllowDynamicProperties() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  true,
+	}
+}
+
+// ComponentValidationCustomizeDiff returns a CustomizeDiff validating the properties map and, when relationshipsKey is set,
+// the relationship list of the component block.
+func ComponentValidationCustomizeDiff(kind nifi.ComponentKind, propertiesKey string, relationshipsKey string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if d.Id() != "" && !d.HasChange("component") && !d.HasChange("allow_dynamic_properties") {
+			return nil
+		}
+		client, ok := meta.(*nifi.Client)
+		if !ok || client == nil {
+			return nil
+		}
+		if !d.NewValueKnown("component.0.type") {
+			return nil
+		}
+		componentType := d.Get("component.0.type").(string)
+
+		definition, err := client.GetComponentDefinition(kind, componentType, nifi.Bundle{})
+		if err != nil {
+			log.Printf("[WARN] Definition of %s is not available, skipping validation: %s", componentType, err)
+			return nil
+		}
+
+		errors := []string{}
+		if d.NewValueKnown(propertiesKey) {
+			properties := d.Get(propertiesKey).(map[string]interface{})
+			allowDynamic := d.Get("allow_dynamic_properties").(bool)
+			errors = append(errors, ComponentValidateProperties(definition, properties, allowDynamic)...)
+		}
+		if relationshipsKey != "" && d.NewValueKnown(relationshipsKey) {
+			relationships := d.Get(relationshipsKey).([]interface{})
+			errors = append(errors, ComponentValidateRelationships(definition, relationships)...)
+		}
+		if len(errors) > 0 {
+			return fmt.Errorf("invalid configuration of %s:\n  %s", componentType, strings.Join(errors, "\n  "))
+		}
+		return nil
+	}
+}
+
+func ComponentValidateProperties(definition *nifi.ComponentDefinition, properties map[string]interface{}, allowDynamic bool) []string {
+	errors := []string{}
+
+	names := []string{}
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value, _ := properties[name].(string)
+		descriptor, found := definition.PropertyDescriptors[name]
+		if !found {
+			if allowDynamic && definition.SupportsDynamicProperties {
+				continue
+			}
+			hint := ""
+			for _, v := range definition.PropertyDescriptors {
+				if strings.EqualFold(v.DisplayName, name) {
+					hint = fmt.Sprintf(", did you mean %q?", v.Name)
+					break
+				}
+			}
+			if hint == "" && definition.SupportsDynamicProperties {
+				hint = ", dynamic properties are rejected since allow_dynamic_properties is false"
+			}
+			errors = append(errors, fmt.Sprintf("unknown property %q%s", name, hint))
+			continue
+		}
+		if len(descriptor.AllowableValues) == 0 || value == "" || ComponentPropertyIsExpression(value) {
+			continue
+		}
+		allowed := []string{}
+		valid := false
+		for _, v := range descriptor.AllowableValues {
+			allowed = append(allowed, v.Value)
+			if v.Value == value {
+				valid = true
+			}
+		}
+		if !valid {
+			errors = append(errors, fmt.Sprintf("property %q must be one of %v, got %q", name, allowed, value))
+		}
+	}
+
+	required := []string{}
+	for name, descriptor := range definition.PropertyDescriptors {
+		if !descriptor.Required || descriptor.DefaultValue != "" {
+			continue
+		}
+		if !ComponentPropertyDependenciesSatisfied(definition, name, properties, map[string]bool{}) {
+			continue
+		}
+		if _, found := properties[name]; !found {
+			required = append(required, name)
+		}
+	}
+	sort.Strings(required)
+	for _, name := range required {
+		errors = append(errors, fmt.Sprintf("missing required property %q", name))
+	}
+
+	return errors
+}
+
+func ComponentValidateRelationships(definition *nifi.ComponentDefinition, relationships []interface{}) []string {
+	errors := []string{}
+	if definition.SupportsDynamicRelationships {
+		return errors
+	}
+	for _, v := range relationships {
+		name := v.(string)
+		if definition.FindRelationship(name) == nil {
+			supported := []string{}
+			for _, relationship := range definition.SupportedRelationships {
+				supported = append(supported, relationship.Name)
+			}
+			errors = append(errors, fmt.Sprintf("unknown relationship %q, supported relationships are %v", name, supported))
+		}
+	}
+	return errors
+}
+
+// NiFi only requires a property when each property it depends on is set, to one of the dependent values when any
+// are given, and is itself relevant. The configured value is used, or the default value when not configured.
+// Dependencies on values only known to NiFi are not considered satisfied.
+func ComponentPropertyDependenciesSatisfied(definition *nifi.ComponentDefinition, name string, properties map[string]interface{}, visiting map[string]bool) bool {
+	if visiting[name] {
+		return false
+	}
+	visiting[name] = true
+	defer delete(visiting, name)
+
+	for _, dependency := range definition.PropertyDescriptors[name].Dependencies {
+		value, _ := properties[dependency.PropertyName].(string)
+		if value == "" {
+			value = definition.PropertyDescriptors[dependency.PropertyName].DefaultValue
+		}
+		if value == "" || ComponentPropertyIsExpression(value) {
+			return false
+		}
+		matched := len(dependency.DependentValues) == 0
+		for _, v := range dependency.DependentValues {
+			if v == value {
+				matched = true
+				break
+			}
+		}
+		if !matched || !ComponentPropertyDependenciesSatisfied(definition, dependency.PropertyName, properties, visiting) {
+			return false
+		}
+	}
+	return true
+}
+
+// Values computed by the expression language or taken from parameters are only known to NiFi.
+func ComponentPropertyIsExpression(value string) bool {
+	return strings.Contains(value, "${") || strings.Contains(value, "#{")
+}
+
+// Validation status
+
+const (
+	ComponentValidationSeverity_WARNING = "warning"
+	ComponentValidationSeverity_ERROR   = "error"
+)
+
+// NiFi validates components asynchronously, the status is polled while it is VALIDATING.
+const ComponentValidationPollInterval = 3 * time.Second
+const ComponentValidationPolls = 10
+
+func SchemaValidationStatus() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+}
+
+func SchemaValidationErrors() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+}
+
+func SchemaValidationSeverity() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Default:  ComponentValidationSeverity_WARNING,
+		ValidateFunc: validation.StringInSlice([]string{
+			ComponentValidationSeverity_WARNING,
+			ComponentValidationSeverity_ERROR,
+		}, false),
+	}
+}
+
+func ComponentValidationToSchema(d *schema.ResourceData, status nifi.ValidationStatus, validationErrors []string) {
+	errors := []interface{}{}
+	for _, v := range validationErrors {
+		errors = append(errors, v)
+	}
+	d.Set("validation_status", string(status))
+	d.Set("validation_errors", errors)
+}
+
+// ComponentValidationApply wraps a create or update function and reports the validation errors of the applied
+// component as warnings or errors, depending on validation_severity.
+func ComponentValidationApply(apply func(*schema.ResourceData, interface{}) error, read func(*schema.ResourceData, interface{}) error) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		err := apply(d, meta)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if d.Id() == "" {
+			return nil
+		}
+		for i := 0; i < ComponentValidationPolls; i++ {
+			if d.Get("validation_status").(string) != string(nifi.ValidationStatus_VALIDATING) {
+				break
+			}
+			time.Sleep(ComponentValidationPollInterval)
+			err = read(d, meta)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		return ComponentValidationDiagnostics(d)
+	}
+}
+
+func ComponentValidationDiagnostics(d *schema.ResourceData) diag.Diagnostics {
+	severity := diag.Warning
+	if d.Get("validation_severity").(string) == ComponentValidationSeverity_ERROR {
+		severity = diag.Error
+	}
+	name := d.Get("component.0.name").(string)
+	status := d.Get("validation_status").(string)
+
+	var diags diag.Diagnostics
+	for _, v := range d.Get("validation_errors").([]interface{}) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: severity,
+			Summary:  fmt.Sprintf("%s is %s", name, status),
+			Detail:   v.(string),
+		})
+	}
+	return diags
+}
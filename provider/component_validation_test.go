@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"testing"
+
+	nifi "github.com/glympse/terraform-provider-nifi/nifi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComponentValidatePropertiesDependencies(t *testing.T) {
+	// PutDatabaseRecord only requires the record path when the statement type is taken from it
+	definition := &nifi.ComponentDefinition{
+		PropertyDescriptors: map[string]nifi.PropertyDescriptor{
+			"put-db-record-statement-type": {
+				Name:         "put-db-record-statement-type",
+				Required:     true,
+				DefaultValue: "INSERT",
+			},
+			"Statement Type Record Path": {
+				Name:     "Statement Type Record Path",
+				Required: true,
+				Dependencies: []nifi.PropertyDependency{{
+					PropertyName:    "put-db-record-statement-type",
+					DependentValues: []string{"Use Record Path"},
+				}},
+			},
+			"Data Record Path": {
+				Name:     "Data Record Path",
+				Required: true,
+				Dependencies: []nifi.PropertyDependency{{
+					PropertyName: "Statement Type Record Path",
+				}},
+			},
+		},
+	}
+
+	errors := ComponentValidateProperties(definition, map[string]interface{}{}, true)
+	assert.Empty(t, errors)
+
+	errors = ComponentValidateProperties(definition, map[string]interface{}{
+		"put-db-record-statement-type": "UPDATE",
+	}, true)
+	assert.Empty(t, errors)
+
+	errors = ComponentValidateProperties(definition, map[string]interface{}{
+		"put-db-record-statement-type": "Use Record Path",
+	}, true)
+	assert.Equal(t, []string{`missing required property "Statement Type Record Path"`}, errors)
+
+	errors = ComponentValidateProperties(definition, map[string]interface{}{
+		"put-db-record-statement-type": "Use Record Path",
+		"Statement Type Record Path":   "/type",
+	}, true)
+	assert.Equal(t, []string{`missing required property "Data Record Path"`}, errors)
+
+	// Values only known to NiFi do not make dependent properties required
+	errors = ComponentValidateProperties(definition, map[string]interface{}{
+		"put-db-record-statement-type": "#{statement.type}",
+	}, true)
+	assert.Empty(t, errors)
+}
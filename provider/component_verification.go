@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	nifi "github.com/glympse/terraform-provider-nifi/nifi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Configuration verification is opt-in: the component is verified by NiFi with its configured properties
+// before it is started or enabled. Requires NiFi 1.16 or later.
+
+func SchemaVerify() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"attributes": {
+					Type:     schema.TypeMap,
+					Optional: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
+func ComponentVerify(client *nifi.Client, d *schema.ResourceData, kind nifi.ComponentKind, componentId string,
+	properties map[string]interface{}, timeout time.Duration) error {
+	v := d.Get("verify").([]interface{})
+	if len(v) == 0 {
+		return nil
+	}
+	attributes := map[string]string{}
+	if verify, ok := v[0].(map[string]interface{}); ok {
+		for k, v := range verify["attributes"].(map[string]interface{}) {
+			attributes[k] = v.(string)
+		}
+	}
+
+	log.Printf("[INFO] Verifying configuration of %s %s", kind, componentId)
+	results, err := client.VerifyComponentConfig(kind, componentId, properties, attributes, timeout)
+	if err != nil {
+		return err
+	}
+	failures := []string{}
+	for _, result := range results {
+		switch result.Outcome {
+		case nifi.ConfigVerificationOutcome_FAILED:
+			failures = append(failures, fmt.Sprintf("%s: %s", result.VerificationStepName, result.Explanation))
+		case nifi.ConfigVerificationOutcome_SKIPPED:
+			log.Printf("[INFO] Verification step skipped for %s: %s, %s", componentId, result.VerificationStepName, result.Explanation)
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("configuration verification failed:\n  %s", strings.Join(failures, "\n  "))
+	}
+	return nil
+}
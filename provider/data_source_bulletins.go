@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	nifi "github.com/glympse/terraform-provider-nifi/nifi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func DataSourceBulletins() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: DataSourceBulletinsRead,
+		Schema: map[string]*schema.Schema{
+			"group_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"component_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"source_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"after_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"generated": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"bulletins": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"group_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"source_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"source_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"category": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"level": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"message": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"timestamp": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"node_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func DataSourceBulletinsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	filter := nifi.BulletinFilter{
+		GroupId:    d.Get("group_id").(string),
+		SourceId:   d.Get("component_id").(string),
+		SourceName: d.Get("source_name").(string),
+		After:      int64(d.Get("after_id").(int)),
+		Limit:      d.Get("limit").(int),
+	}
+
+	client := meta.(*nifi.Client)
+	board, err := client.GetBulletinBoard(filter)
+	if err != nil {
+		return diag.Errorf("error retrieving bulletin board: %s", err)
+	}
+
+	// Bulletins of components the user cannot read have no details
+	bulletins := []interface{}{}
+	for _, v := range board.BulletinBoard.Bulletins {
+		bulletin := map[string]interface{}{
+			"id":        v.Id,
+			"group_id":  v.GroupId,
+			"source_id": v.SourceId,
+		}
+		if v.Bulletin != nil {
+			bulletin["source_name"] = v.Bulletin.SourceName
+			bulletin["category"] = v.Bulletin.Category
+			bulletin["level"] = v.Bulletin.Level
+			bulletin["message"] = v.Bulletin.Message
+			bulletin["timestamp"] = v.Bulletin.Timestamp
+			bulletin["node_address"] = v.Bulletin.NodeAddress
+		}
+		bulletins = append(bulletins, bulletin)
+	}
+
+	d.SetId(strconv.FormatInt(time.Now().UnixNano(), 10))
+	d.Set("generated", board.BulletinBoard.Generated)
+	d.Set("bulletins", bulletins)
+	return nil
+}
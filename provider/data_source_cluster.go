@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"context"
+
+	nifi "github.com/glympse/terraform-provider-nifi/nifi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceCluster() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: DataSourceClusterRead,
+		Schema: map[string]*schema.Schema{
+			"generated": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"nodes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"node_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"api_port": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"heartbeat": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"roles": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"primary": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"coordinator": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"active_thread_count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"queued": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"node_start_time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func DataSourceClusterRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*nifi.Client)
+	cluster, err := client.GetCluster()
+	if err != nil {
+		return diag.Errorf("error retrieving cluster: %s", err)
+	}
+
+	nodes := []interface{}{}
+	for _, node := range cluster.Cluster.Nodes {
+		roles := []interface{}{}
+		for _, v := range node.Roles {
+			roles = append(roles, v)
+		}
+		nodes = append(nodes, map[string]interface{}{
+			"node_id":             node.NodeId,
+			"address":             node.Address,
+			"api_port":            node.ApiPort,
+			"status":              string(node.Status),
+			"heartbeat":           node.Heartbeat,
+			"roles":               roles,
+			"primary":             node.HasRole(nifi.ClusterNodeRole_PRIMARY),
+			"coordinator":         node.HasRole(nifi.ClusterNodeRole_COORDINATOR),
+			"active_thread_count": node.ActiveThreadCount,
+			"queued":              node.Queued,
+			"node_start_time":     node.NodeStartTime,
+		})
+	}
+
+	d.SetId("cluster")
+	d.Set("generated", cluster.Cluster.Generated)
+	d.Set("nodes", nodes)
+	return nil
+}
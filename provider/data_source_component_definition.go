@@ -0,0 +1,178 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	nifi "github.com/glympse/terraform-provider-nifi/nifi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func DataSourceComponentDefinition() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: DataSourceComponentDefinitionRead,
+		Schema: map[string]*schema.Schema{
+			"kind": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"processor", "controller_service", "reporting_task",
+				}, false),
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"bundle_group": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"bundle_artifact": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"bundle_version": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"supports_dynamic_properties": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"supports_dynamic_relationships": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"property_descriptors": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"display_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"default_value": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"allowable_values": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"required": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"sensitive": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"expression_language_scope": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"identifies_controller_service": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"relationships": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func DataSourceComponentDefinitionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	kind := nifi.ComponentKind(strings.ToUpper(d.Get("kind").(string)))
+	componentType := d.Get("type").(string)
+	bundle := nifi.Bundle{
+		Group:    d.Get("bundle_group").(string),
+		Artifact: d.Get("bundle_artifact").(string),
+		Version:  d.Get("bundle_version").(string),
+	}
+
+	client := meta.(*nifi.Client)
+	definition, err := client.GetComponentDefinition(kind, componentType, bundle)
+	if err != nil {
+		return diag.Errorf("error retrieving definition of %s: %s", componentType, err)
+	}
+
+	names := []string{}
+	for name := range definition.PropertyDescriptors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	descriptors := []interface{}{}
+	for _, name := range names {
+		descriptor := definition.PropertyDescriptors[name]
+		allowableValues := []interface{}{}
+		for _, v := range descriptor.AllowableValues {
+			allowableValues = append(allowableValues, v.Value)
+		}
+		identifiesControllerService := ""
+		if descriptor.TypeProvidedByValue != nil {
+			identifiesControllerService = descriptor.TypeProvidedByValue.Type
+		}
+		descriptors = append(descriptors, map[string]interface{}{
+			"name":                          descriptor.Name,
+			"display_name":                  descriptor.DisplayName,
+			"description":                   descriptor.Description,
+			"default_value":                 descriptor.DefaultValue,
+			"allowable_values":              allowableValues,
+			"required":                      descriptor.Required,
+			"sensitive":                     descriptor.Sensitive,
+			"expression_language_scope":     descriptor.ExpressionLanguageScope,
+			"identifies_controller_service": identifiesControllerService,
+		})
+	}
+	relationships := []interface{}{}
+	for _, relationship := range definition.SupportedRelationships {
+		relationships = append(relationships, map[string]interface{}{
+			"name":        relationship.Name,
+			"description": relationship.Description,
+		})
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s:%s:%s", definition.Group, definition.Artifact, definition.Version, definition.Type))
+	d.Set("bundle_group", definition.Group)
+	d.Set("bundle_artifact", definition.Artifact)
+	d.Set("bundle_version", definition.Version)
+	d.Set("supports_dynamic_properties", definition.SupportsDynamicProperties)
+	d.Set("supports_dynamic_relationships", definition.SupportsDynamicRelationships)
+	d.Set("property_descriptors", descriptors)
+	d.Set("relationships", relationships)
+	return nil
+}
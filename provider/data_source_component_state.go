@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	nifi "github.com/glympse/terraform-provider-nifi/nifi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func DataSourceComponentState() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: DataSourceComponentStateRead,
+		Schema: map[string]*schema.Schema{
+			"kind": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "processor",
+				ValidateFunc: validation.StringInSlice([]string{
+					"processor", "controller_service", "reporting_task",
+				}, false),
+			},
+			"component_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"state_description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"cluster_state": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"cluster_total_entry_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"local_state": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"value": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cluster_node_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cluster_node_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"local_total_entry_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func DataSourceComponentStateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	kind := nifi.ComponentKind(strings.ToUpper(d.Get("kind").(string)))
+	componentId := d.Get("component_id").(string)
+
+	client := meta.(*nifi.Client)
+	state, err := client.GetComponentState(kind, componentId)
+	if err != nil {
+		return diag.Errorf("error retrieving component state: %s, %s", componentId, err)
+	}
+
+	clusterState := map[string]interface{}{}
+	clusterTotal := 0
+	if v := state.ComponentState.ClusterState; v != nil {
+		for _, entry := range v.State {
+			clusterState[entry.Key] = entry.Value
+		}
+		clusterTotal = v.TotalEntryCount
+	}
+
+	// Local state is kept by each node of a cluster, keys are not unique
+	localState := []interface{}{}
+	localTotal := 0
+	if v := state.ComponentState.LocalState; v != nil {
+		for _, entry := range v.State {
+			localState = append(localState, map[string]interface{}{
+				"key":                  entry.Key,
+				"value":                entry.Value,
+				"cluster_node_id":      entry.ClusterNodeId,
+				"cluster_node_address": entry.ClusterNodeAddress,
+			})
+		}
+		localTotal = v.TotalEntryCount
+	}
+
+	d.SetId(componentId)
+	d.Set("state_description", state.ComponentState.StateDescription)
+	d.Set("cluster_state", clusterState)
+	d.Set("cluster_total_entry_count", clusterTotal)
+	d.Set("local_state", localState)
+	d.Set("local_total_entry_count", localTotal)
+	return nil
+}
@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	nifi "github.com/glympse/terraform-provider-nifi/nifi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceProcessorTypes() *schema.Resource {
+	return DataSourceComponentTypes(nifi.ComponentKind_PROCESSOR)
+}
+
+func DataSourceControllerServiceTypes() *schema.Resource {
+	return DataSourceComponentTypes(nifi.ComponentKind_CONTROLLER_SERVICE)
+}
+
+func DataSourceReportingTaskTypes() *schema.Resource {
+	return DataSourceComponentTypes(nifi.ComponentKind_REPORTING_TASK)
+}
+
+func DataSourceComponentTypes(kind nifi.ComponentKind) *schema.Resource {
+	return &schema.Resource{
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return DataSourceComponentTypesRead(kind, d, meta)
+		},
+		Schema: map[string]*schema.Schema{
+			"bundle_group": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"bundle_artifact": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"tag": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"types": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"bundle_group": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"bundle_artifact": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"bundle_version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"restricted": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"tags": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func DataSourceComponentTypesRead(kind nifi.ComponentKind, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	bundleGroup := d.Get("bundle_group").(string)
+	bundleArtifact := d.Get("bundle_artifact").(string)
+	tag := d.Get("tag").(string)
+
+	client := meta.(*nifi.Client)
+	documentedTypes, err := client.GetComponentTypes(kind, bundleGroup, bundleArtifact)
+	if err != nil {
+		return diag.Errorf("error retrieving %s types: %s", kind, err)
+	}
+
+	types := []interface{}{}
+	for _, documentedType := range documentedTypes {
+		if tag != "" && !ComponentTypeHasTag(documentedType, tag) {
+			continue
+		}
+		tags := []interface{}{}
+		for _, v := range documentedType.Tags {
+			tags = append(tags, v)
+		}
+		types = append(types, map[string]interface{}{
+			"type":            documentedType.Type,
+			"bundle_group":    documentedType.Bundle.Group,
+			"bundle_artifact": documentedType.Bundle.Artifact,
+			"bundle_version":  documentedType.Bundle.Version,
+			"description":     documentedType.Description,
+			"restricted":      documentedType.Restricted,
+			"tags":            tags,
+		})
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s/%s", kind, bundleGroup, bundleArtifact, tag))
+	d.Set("types", types)
+	return nil
+}
+
+func ComponentTypeHasTag(documentedType nifi.DocumentedType, tag string) bool {
+	for _, v := range documentedType.Tags {
+		if strings.EqualFold(v, tag) {
+			return true
+		}
+	}
+	return false
+}
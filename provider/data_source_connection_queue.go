@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	nifi "github.com/glympse/terraform-provider-nifi/nifi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func DataSourceConnectionQueue() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: DataSourceConnectionQueueRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(1 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"connection_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"max_results": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      100,
+				ValidateFunc: validation.IntBetween(1, 100),
+			},
+			"queued_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"queued_bytes": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"flowfiles": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"uuid": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"filename": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"position": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"size": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"queued_duration": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"penalized": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"cluster_node_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cluster_node_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func DataSourceConnectionQueueRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	connectionId := d.Get("connection_id").(string)
+	maxResults := d.Get("max_results").(int)
+
+	client := meta.(*nifi.Client)
+	listingRequest, err := client.ListConnectionQueue(connectionId, d.Timeout(schema.TimeoutRead))
+	if err != nil {
+		return diag.Errorf("error listing Connection queue: %s, %s", connectionId, err)
+	}
+
+	// Queued duration is reported in milliseconds
+	flowFiles := []interface{}{}
+	for _, summary := range listingRequest.ListingRequest.FlowFileSummaries {
+		if len(flowFiles) == maxResults {
+			break
+		}
+		flowFiles = append(flowFiles, map[string]interface{}{
+			"uuid":                 summary.Uuid,
+			"filename":             summary.Filename,
+			"position":             summary.Position,
+			"size":                 summary.Size,
+			"queued_duration":      summary.QueuedDuration,
+			"penalized":            summary.Penalized,
+			"cluster_node_id":      summary.ClusterNodeId,
+			"cluster_node_address": summary.ClusterNodeAddress,
+		})
+	}
+
+	d.SetId(connectionId)
+	d.Set("queued_count", listingRequest.ListingRequest.QueueSize.ObjectCount)
+	d.Set("queued_bytes", listingRequest.ListingRequest.QueueSize.ByteCount)
+	d.Set("flowfiles", flowFiles)
+	return nil
+}
@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"context"
+
+	nifi "github.com/glympse/terraform-provider-nifi/nifi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceConnectionStatus() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: DataSourceConnectionStatusRead,
+		Schema: map[string]*schema.Schema{
+			"connection_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"group_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"flowfiles_queued": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"bytes_queued": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"queued": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"percent_use_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"percent_use_bytes": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"flowfiles_in": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"bytes_in": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"flowfiles_out": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"bytes_out": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func DataSourceConnectionStatusRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	connectionId := d.Get("connection_id").(string)
+
+	client := meta.(*nifi.Client)
+	status, err := client.GetConnectionStatus(connectionId)
+	if err != nil {
+		return diag.Errorf("error retrieving Connection status: %s, %s", connectionId, err)
+	}
+
+	// In and out counters cover the last five minutes
+	snapshot := status.ConnectionStatus.AggregateSnapshot
+	d.SetId(connectionId)
+	d.Set("name", status.ConnectionStatus.Name)
+	d.Set("group_id", status.ConnectionStatus.GroupId)
+	d.Set("flowfiles_queued", snapshot.FlowFilesQueued)
+	d.Set("bytes_queued", snapshot.BytesQueued)
+	d.Set("queued", snapshot.Queued)
+	d.Set("percent_use_count", snapshot.PercentUseCount)
+	d.Set("percent_use_bytes", snapshot.PercentUseBytes)
+	d.Set("flowfiles_in", snapshot.FlowFilesIn)
+	d.Set("bytes_in", snapshot.BytesIn)
+	d.Set("flowfiles_out", snapshot.FlowFilesOut)
+	d.Set("bytes_out", snapshot.BytesOut)
+	return nil
+}
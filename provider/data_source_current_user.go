@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"context"
+
+	nifi "github.com/glympse/terraform-provider-nifi/nifi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceCurrentUser() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"identity": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"anonymous": {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+		"can_version_flows": {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+	}
+	for _, name := range []string{"controller", "policies", "tenants", "provenance", "counters"} {
+		s[name+"_can_read"] = &schema.Schema{
+			Type:     schema.TypeBool,
+			Computed: true,
+		}
+		s[name+"_can_write"] = &schema.Schema{
+			Type:     schema.TypeBool,
+			Computed: true,
+		}
+	}
+	return &schema.Resource{
+		ReadContext: DataSourceCurrentUserRead,
+		Schema:      s,
+	}
+}
+
+func DataSourceCurrentUserRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*nifi.Client)
+	user, err := client.GetCurrentUser()
+	if err != nil {
+		return diag.Errorf("error retrieving current user: %s", err)
+	}
+
+	d.SetId(user.Identity)
+	d.Set("identity", user.Identity)
+	d.Set("anonymous", user.Anonymous)
+	d.Set("can_version_flows", user.CanVersionFlows)
+	permissions := map[string]nifi.Permissions{
+		"controller": user.ControllerPermissions,
+		"policies":   user.PoliciesPermissions,
+		"tenants":    user.TenantsPermissions,
+		"provenance": user.ProvenancePermissions,
+		"counters":   user.CountersPermissions,
+	}
+	for name, v := range permissions {
+		d.Set(name+"_can_read", v.CanRead)
+		d.Set(name+"_can_write", v.CanWrite)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	nifi "github.com/glympse/terraform-provider-nifi/nifi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceProcessGroup() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: DataSourceProcessGroupRead,
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"name", "path"},
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"name", "path"},
+			},
+			"parent_group_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"position": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"x": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						"y": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"parameter_context_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"input_port_ids": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"output_port_ids": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func DataSourceProcessGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*nifi.Client)
+
+	var processGroup *nifi.ProcessGroup
+	var err error
+	if name, ok := d.GetOk("name"); ok {
+		parentGroupId := "root"
+		if v, ok := d.GetOk("parent_group_id"); ok {
+			parentGroupId = v.(string)
+		}
+		processGroup, err = client.FindChildProcessGroup(parentGroupId, name.(string))
+	} else {
+		processGroup, err = ProcessGroupFromPath(client, d.Get("path").(string))
+	}
+	if err != nil {
+		return diag.Errorf("error retrieving Process Group: %s", err)
+	}
+
+	flow, err := client.GetProcessGroupFlow(processGroup.Component.Id)
+	if err != nil {
+		return diag.Errorf("error retrieving Process Group flow: %s", processGroup.Component.Id)
+	}
+
+	inputPortIds := map[string]interface{}{}
+	for _, port := range flow.ProcessGroupFlow.Flow.InputPorts {
+		inputPortIds[port.Component.Name] = port.Component.Id
+	}
+	outputPortIds := map[string]interface{}{}
+	for _, port := range flow.ProcessGroupFlow.Flow.OutputPorts {
+		outputPortIds[port.Component.Name] = port.Component.Id
+	}
+	parameterContextId := ""
+	if processGroup.Component.ParameterContext != nil {
+		parameterContextId = processGroup.Component.ParameterContext.Id
+	}
+
+	d.SetId(processGroup.Component.Id)
+	d.Set("name", processGroup.Component.Name)
+	d.Set("parent_group_id", processGroup.Component.ParentGroupId)
+	d.Set("position", []map[string]interface{}{{
+		"x": processGroup.Component.Position.X,
+		"y": processGroup.Component.Position.Y,
+	}})
+	d.Set("parameter_context_id", parameterContextId)
+	d.Set("input_port_ids", inputPortIds)
+	d.Set("output_port_ids", outputPortIds)
+
+	return nil
+}
+
+// ProcessGroupFromPath resolves `root` or a slash separated path of group names starting at the root group.
+func ProcessGroupFromPath(client *nifi.Client, path string) (*nifi.ProcessGroup, error) {
+	processGroup, err := client.GetProcessGroup("root")
+	if err != nil {
+		return nil, err
+	}
+	names := strings.Split(strings.Trim(path, "/"), "/")
+	if names[0] == "root" {
+		names = names[1:]
+	}
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		processGroup, err = client.FindChildProcessGroup(processGroup.Component.Id, name)
+		if err != nil {
+			return nil, fmt.Errorf("%s (%s in %s)", err, name, path)
+		}
+	}
+	return processGroup, nil
+}
@@ -0,0 +1,237 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	nifi "github.com/glympse/terraform-provider-nifi/nifi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Search terms are sent in the form introduced by NiFi 1.13, earlier versions reject the query.
+func DataSourceProvenanceEvents() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: DataSourceProvenanceEventsRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(2 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"component_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"flowfile_uuid": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"filename": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"start_date": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"end_date": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"max_results": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      100,
+				ValidateFunc: validation.IntBetween(1, 10000),
+			},
+			"total_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"events": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"event_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"event_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"event_time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"event_duration": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"lineage_duration": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"flowfile_uuid": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"parent_uuids": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"child_uuids": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"file_size_bytes": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"group_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"component_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"component_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"component_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"relationship": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"transit_uri": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"alternate_identifier_uri": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"source_system_flowfile_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"details": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cluster_node_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cluster_node_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"attributes": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func DataSourceProvenanceEventsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	request := nifi.ProvenanceRequest{
+		MaxResults:  d.Get("max_results").(int),
+		SearchTerms: map[string]nifi.ProvenanceSearchTerm{},
+	}
+	searchTerms := map[string]string{
+		"component_id":  nifi.ProvenanceSearchTerm_PROCESSOR_ID,
+		"flowfile_uuid": nifi.ProvenanceSearchTerm_FLOWFILE_UUID,
+		"filename":      nifi.ProvenanceSearchTerm_FILENAME,
+	}
+	for key, term := range searchTerms {
+		if v, ok := d.GetOk(key); ok {
+			request.SearchTerms[term] = nifi.ProvenanceSearchTerm{Value: v.(string)}
+		}
+	}
+	// NiFi expects dates in its own format, with a precision of one second
+	if v, ok := d.GetOk("start_date"); ok {
+		date, _ := time.Parse(time.RFC3339, v.(string))
+		request.StartDate = date.UTC().Format(nifi.ProvenanceDateFormat)
+	}
+	if v, ok := d.GetOk("end_date"); ok {
+		date, _ := time.Parse(time.RFC3339, v.(string))
+		request.EndDate = date.UTC().Format(nifi.ProvenanceDateFormat)
+	}
+
+	client := meta.(*nifi.Client)
+	provenance, err := client.QueryProvenance(request, d.Timeout(schema.TimeoutRead))
+	if err != nil {
+		return diag.Errorf("error querying provenance: %s", err)
+	}
+
+	events := []interface{}{}
+	for _, event := range provenance.Provenance.Results.ProvenanceEvents {
+		// Attributes removed by the event have no value
+		attributes := map[string]interface{}{}
+		for _, attribute := range event.Attributes {
+			if attribute.Value != nil {
+				attributes[attribute.Name] = *attribute.Value
+			}
+		}
+		parentUuids := []interface{}{}
+		for _, v := range event.ParentUuids {
+			parentUuids = append(parentUuids, v)
+		}
+		childUuids := []interface{}{}
+		for _, v := range event.ChildUuids {
+			childUuids = append(childUuids, v)
+		}
+		events = append(events, map[string]interface{}{
+			"id":                        event.Id,
+			"event_id":                  event.EventId,
+			"event_type":                event.EventType,
+			"event_time":                event.EventTime,
+			"event_duration":            event.EventDuration,
+			"lineage_duration":          event.LineageDuration,
+			"flowfile_uuid":             event.FlowFileUuid,
+			"parent_uuids":              parentUuids,
+			"child_uuids":               childUuids,
+			"file_size_bytes":           event.FileSizeBytes,
+			"group_id":                  event.GroupId,
+			"component_id":              event.ComponentId,
+			"component_type":            event.ComponentType,
+			"component_name":            event.ComponentName,
+			"relationship":              event.Relationship,
+			"transit_uri":               event.TransitUri,
+			"alternate_identifier_uri":  event.AlternateIdentifierUri,
+			"source_system_flowfile_id": event.SourceSystemFlowFileId,
+			"details":                   event.Details,
+			"cluster_node_id":           event.ClusterNodeId,
+			"cluster_node_address":      event.ClusterNodeAddress,
+			"attributes":                attributes,
+		})
+	}
+
+	d.SetId(strconv.FormatInt(time.Now().UnixNano(), 10))
+	d.Set("total_count", provenance.Provenance.Results.TotalCount)
+	d.Set("events", events)
+	return nil
+}
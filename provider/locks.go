@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"log"
+
+	nifi "github.com/glympse/terraform-provider-nifi/nifi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Users and groups reference each other, their changes are serialized.
+const TenantsLockKey = "tenants"
+
+// ComponentLock locks a component, its current and desired parent groups and the other ends of the connections
+// linking it in those groups.
+func ComponentLock(client *nifi.Client, d *schema.ResourceData) func() {
+	componentId := d.Id()
+	keys := []string{componentId}
+	for _, groupId := range []string{d.Get("parent_group_id").(string), d.Get("component.0.parent_group_id").(string)} {
+		if groupId == "" {
+			continue
+		}
+		keys = append(keys, groupId)
+		connections, err := client.GetProcessGroupConnections(groupId)
+		if err != nil {
+			log.Printf("[WARN] Unable to retrieve connections of %s to lock %s: %s", groupId, componentId, err)
+			continue
+		}
+		for _, connection := range connections.Connections {
+			source := connection.Component.Source.Id
+			destination := connection.Component.Destination.Id
+			if source == componentId || destination == componentId {
+				keys = append(keys, source, destination)
+			}
+		}
+	}
+	return client.LockKeys(keys...)
+}
+
+// ConnectionLock locks a connection, its parent group and its current and desired endpoints.
+func ConnectionLock(client *nifi.Client, d *schema.ResourceData) func() {
+	keys := []string{d.Id(), d.Get("parent_group_id").(string)}
+	for _, key := range []string{"component.0.source.0.id", "component.0.destination.0.id"} {
+		current, desired := d.GetChange(key)
+		keys = append(keys, current.(string), desired.(string))
+	}
+	return client.LockKeys(keys...)
+}
@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	nifi "github.com/glympse/terraform-provider-nifi/nifi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Most resource operations wrap NiFi errors into their own messages. Operations run with a client tracking
+// permission denied responses, so that failures caused by missing access policies are reported as such.
+
+func PermissionTrackedResource(r *schema.Resource) *schema.Resource {
+	if r.Create != nil {
+		r.Create = permissionTracked(r.Create)
+	}
+	if r.Read != nil {
+		r.Read = permissionTracked(r.Read)
+	}
+	if r.Update != nil {
+		r.Update = permissionTracked(r.Update)
+	}
+	if r.Delete != nil {
+		r.Delete = permissionTracked(r.Delete)
+	}
+	if r.CreateContext != nil {
+		r.CreateContext = permissionTrackedContext(r.CreateContext)
+	}
+	if r.ReadContext != nil {
+		r.ReadContext = permissionTrackedContext(r.ReadContext)
+	}
+	if r.UpdateContext != nil {
+		r.UpdateContext = permissionTrackedContext(r.UpdateContext)
+	}
+	if r.DeleteContext != nil {
+		r.DeleteContext = permissionTrackedContext(r.DeleteContext)
+	}
+	return r
+}
+
+func permissionTracked(f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+	return func(d *schema.ResourceData, meta interface{}) error {
+		client, ok := meta.(*nifi.Client)
+		if !ok {
+			return f(d, meta)
+		}
+		tracked := client.TrackPermissions()
+		err := f(d, tracked)
+		if err == nil {
+			return nil
+		}
+		denied := PermissionDeniedMessages(tracked, err.Error())
+		if len(denied) == 0 {
+			return err
+		}
+		return fmt.Errorf("%s\n%s", err, strings.Join(denied, "\n"))
+	}
+}
+
+func permissionTrackedContext(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		client, ok := meta.(*nifi.Client)
+		if !ok {
+			return f(ctx, d, meta)
+		}
+		tracked := client.TrackPermissions()
+		diags := f(ctx, d, tracked)
+		if !diags.HasError() {
+			return diags
+		}
+		reported := []string{}
+		for _, v := range diags {
+			reported = append(reported, v.Summary, v.Detail)
+		}
+		for _, message := range PermissionDeniedMessages(tracked, strings.Join(reported, "\n")) {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Permission denied",
+				Detail:   message,
+			})
+		}
+		return diags
+	}
+}
+
+// PermissionDeniedMessages describes the recorded permission denied errors that are not already part of the reported error.
+func PermissionDeniedMessages(client *nifi.Client, reported string) []string {
+	messages := []string{}
+	seen := map[string]bool{}
+	for _, denied := range client.PermissionDenied() {
+		message := fmt.Sprintf("permission denied: %s access to %s is required (%s %s)",
+			denied.Action, denied.Policy, denied.Method, denied.Url)
+		if seen[message] || strings.Contains(reported, denied.Error()) {
+			continue
+		}
+		seen[message] = true
+		messages = append(messages, message)
+	}
+	return messages
+}
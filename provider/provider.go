@@ -2,10 +2,14 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"log"
+	"time"
 
 	nifi "github.com/glympse/terraform-provider-nifi/nifi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // Provider returns a terraform.ResourceProvider.
@@ -48,6 +52,12 @@ func Provider() *schema.Provider {
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("NIFI_ADMIN_KEY", ""),
 			},
+			"bulletin_warning_delay": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
@@ -61,8 +71,32 @@ func Provider() *schema.Provider {
 			"nifi_remote_process_group": ResourceRemoteProcessGroup(),
 			"nifi_funnel":               ResourceFunnel(),
 			"nifi_reporting_task":       ResourceReportingTask(),
+			"nifi_queue_drained":        ResourceQueueDrained(),
+			"nifi_controller_config":    ResourceControllerConfig(),
+			"nifi_cluster_node":         ResourceClusterNode(),
+		},
+
+		DataSourcesMap: map[string]*schema.Resource{
+			"nifi_process_group":            DataSourceProcessGroup(),
+			"nifi_processor_types":          DataSourceProcessorTypes(),
+			"nifi_controller_service_types": DataSourceControllerServiceTypes(),
+			"nifi_reporting_task_types":     DataSourceReportingTaskTypes(),
+			"nifi_component_definition":     DataSourceComponentDefinition(),
+			"nifi_connection_status":        DataSourceConnectionStatus(),
+			"nifi_connection_queue":         DataSourceConnectionQueue(),
+			"nifi_current_user":             DataSourceCurrentUser(),
+			"nifi_cluster":                  DataSourceCluster(),
+			"nifi_provenance_events":        DataSourceProvenanceEvents(),
+			"nifi_bulletins":                DataSourceBulletins(),
+			"nifi_component_state":          DataSourceComponentState(),
 		},
 	}
+	for _, r := range p.ResourcesMap {
+		PermissionTrackedResource(r)
+	}
+	for _, r := range p.DataSourcesMap {
+		PermissionTrackedResource(r)
+	}
 	p.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 		return providerConfigure(d, p.TerraformVersion)
 	}
@@ -78,10 +112,23 @@ func providerConfigure(d *schema.ResourceData, terraformVersion string) (interfa
 		AdminKeyPath:  d.Get("admin_key").(string),
 		Username:      d.Get("username").(string),
 		Password:      d.Get("password").(string),
+
+		BulletinWarningDelay: time.Duration(d.Get("bulletin_warning_delay").(int)) * time.Second,
 	}
 	client, err := nifi.NewClient(config)
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
+
+	// Fail fast on authentication and authorization problems
+	user, err := client.GetCurrentUser()
+	if err != nil {
+		return nil, diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Unable to access NiFi at %s", config.Host),
+			Detail:   err.Error(),
+		}}
+	}
+	log.Printf("[INFO] Connected to NiFi as %s", user.Identity)
 	return client, nil
 }
@@ -0,0 +1,167 @@
+package provider
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	nifi "github.com/glympse/terraform-provider-nifi/nifi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Nodes join the cluster on their own, nifi_cluster_node adopts an existing node by its address,
+// drives its status and removes it from the cluster on destroy.
+
+func ResourceClusterNode() *schema.Resource {
+	return &schema.Resource{
+		Create: ResourceClusterNodeCreate,
+		Read:   ResourceClusterNodeRead,
+		Update: ResourceClusterNodeUpdate,
+		Delete: ResourceClusterNodeDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"address": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"api_port": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  string(nifi.ClusterNodeStatus_CONNECTED),
+				ValidateFunc: validation.StringInSlice([]string{
+					string(nifi.ClusterNodeStatus_CONNECTED),
+					string(nifi.ClusterNodeStatus_DISCONNECTED),
+					string(nifi.ClusterNodeStatus_OFFLOADED),
+				}, false),
+			},
+			"offload_on_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"node_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"roles": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"primary": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"coordinator": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func ResourceClusterNodeCreate(d *schema.ResourceData, meta interface{}) error {
+	address := d.Get("address").(string)
+	apiPort := d.Get("api_port").(int)
+
+	client := meta.(*nifi.Client)
+	node, err := client.FindClusterNode(address, apiPort)
+	if err != nil {
+		return fmt.Errorf("Error retrieving cluster node: %s, %s", address, err)
+	}
+	d.SetId(node.NodeId)
+
+	status := nifi.ClusterNodeStatus(d.Get("status").(string))
+	err = client.SetClusterNodeStatus(node.NodeId, status, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return fmt.Errorf("Failed to change cluster node status: %s, %s", address, err)
+	}
+
+	return ResourceClusterNodeRead(d, meta)
+}
+
+func ResourceClusterNodeRead(d *schema.ResourceData, meta interface{}) error {
+	nodeId := d.Id()
+
+	client := meta.(*nifi.Client)
+	node, err := client.GetClusterNode(nodeId)
+	if err != nil {
+		if "not_found" == err.Error() {
+			log.Printf("[INFO] Cluster node %s no longer exists, removing from state...", nodeId)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error retrieving cluster node: %s", nodeId)
+	}
+
+	roles := []interface{}{}
+	for _, v := range node.Node.Roles {
+		roles = append(roles, v)
+	}
+	d.Set("node_id", node.Node.NodeId)
+	d.Set("address", node.Node.Address)
+	d.Set("api_port", node.Node.ApiPort)
+	d.Set("status", string(node.Node.Status))
+	d.Set("roles", roles)
+	d.Set("primary", node.Node.HasRole(nifi.ClusterNodeRole_PRIMARY))
+	d.Set("coordinator", node.Node.HasRole(nifi.ClusterNodeRole_COORDINATOR))
+
+	return nil
+}
+
+func ResourceClusterNodeUpdate(d *schema.ResourceData, meta interface{}) error {
+	nodeId := d.Id()
+
+	if d.HasChange("status") {
+		client := meta.(*nifi.Client)
+		status := nifi.ClusterNodeStatus(d.Get("status").(string))
+		err := client.SetClusterNodeStatus(nodeId, status, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return fmt.Errorf("Failed to change cluster node status: %s, %s", nodeId, err)
+		}
+	}
+
+	return ResourceClusterNodeRead(d, meta)
+}
+
+func ResourceClusterNodeDelete(d *schema.ResourceData, meta interface{}) error {
+	nodeId := d.Id()
+	log.Printf("[INFO] Removing cluster node: %s", nodeId)
+
+	// Only disconnected or offloaded nodes can be removed
+	status := nifi.ClusterNodeStatus_DISCONNECTED
+	if d.Get("offload_on_destroy").(bool) {
+		status = nifi.ClusterNodeStatus_OFFLOADED
+	}
+	client := meta.(*nifi.Client)
+	err := client.SetClusterNodeStatus(nodeId, status, d.Timeout(schema.TimeoutDelete))
+	if err != nil {
+		if "not_found" == err.Error() {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Failed to change cluster node status: %s, %s", nodeId, err)
+	}
+
+	err = client.DeleteClusterNode(nodeId)
+	if err != nil {
+		return fmt.Errorf("Error removing cluster node: %s, %s", nodeId, err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -3,9 +3,17 @@ package provider
 import (
 	"fmt"
 	"log"
+	"time"
 
 	nifi "github.com/glympse/terraform-provider-nifi/nifi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	ConnectionDeletePolicy_DROP              = "drop"
+	ConnectionDeletePolicy_DRAIN             = "drain"
+	ConnectionDeletePolicy_FAIL_IF_NOT_EMPTY = "fail_if_not_empty"
 )
 
 func ResourceConnection() *schema.Resource {
@@ -16,9 +24,21 @@ func ResourceConnection() *schema.Resource {
 		Delete: ResourceConnectionDelete,
 		Exists: ResourceConnectionExists,
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"parent_group_id": SchemaParentGroupId(),
 			"revision":        SchemaRevision(),
+			"delete_policy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      ConnectionDeletePolicy_DROP,
+				ValidateFunc: ValidateConnectionDeletePolicy,
+			},
 			"component": {
 				Type:     schema.TypeList,
 				Required: true,
@@ -44,8 +64,9 @@ func ResourceConnection() *schema.Resource {
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"type": {
-										Type:     schema.TypeString,
-										Required: true,
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: ValidateConnectionHandType,
 									},
 									"id": {
 										Type:     schema.TypeString,
@@ -64,8 +85,9 @@ func ResourceConnection() *schema.Resource {
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"type": {
-										Type:     schema.TypeString,
-										Required: true,
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: ValidateConnectionHandType,
 									},
 									"id": {
 										Type:     schema.TypeString,
@@ -106,6 +128,21 @@ func ResourceConnection() *schema.Resource {
 	}
 }
 
+var ValidateConnectionDeletePolicy = validation.StringInSlice([]string{
+	ConnectionDeletePolicy_DROP,
+	ConnectionDeletePolicy_DRAIN,
+	ConnectionDeletePolicy_FAIL_IF_NOT_EMPTY,
+}, false)
+
+var ValidateConnectionHandType = validation.StringInSlice([]string{
+	string(nifi.ConnectionHand_Type_PROCESSOR),
+	string(nifi.ConnectionHand_Type_REMOTE_INPUT_PORT),
+	string(nifi.ConnectionHand_Type_REMOTE_OUTPUT_PORT),
+	string(nifi.ConnectionHand_Type_INPUT_PORT),
+	string(nifi.ConnectionHand_Type_OUTPUT_PORT),
+	string(nifi.ConnectionHand_Type_FUNNEL),
+}, false)
+
 func ResourceConnectionCreate(d *schema.ResourceData, meta interface{}) error {
 	connection := nifi.Connection{}
 	connection.Revision.Version = 0
@@ -115,14 +152,19 @@ func ResourceConnectionCreate(d *schema.ResourceData, meta interface{}) error {
 	}
 	parentGroupId := connection.Component.ParentGroupId
 
-	// Create connection
+	// Remote process groups list their ports asynchronously after creation
 	client := meta.(*nifi.Client)
+	err = ConnectionWaitRemotePorts(client, &connection, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return err
+	}
+
+	// Create connection
 	err = client.CreateConnection(&connection)
 	if err != nil {
 		return fmt.Errorf("failed to create Connection %s", err)
 	}
-	client.StartConnectionHand(&connection.Component.Source)
-	client.StartConnectionHand(&connection.Component.Destination)
+	ConnectionStartHands(client, &connection)
 	// Indicate successful creation
 	d.SetId(connection.Component.Id)
 	d.Set("parent_group_id", parentGroupId)
@@ -149,10 +191,10 @@ func ResourceConnectionRead(d *schema.ResourceData, meta interface{}) error {
 
 func ResourceConnectionUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*nifi.Client)
-	client.Lock.Lock()
+	unlock := ConnectionLock(client, d)
 	log.Printf("[INFO] Updating Connection: %s...", d.Id())
 	err := ResourceConnectionUpdateInternal(d, meta)
-	defer client.Lock.Unlock()
+	defer unlock()
 	if err == nil {
 		log.Printf("[INFO] Connection updated: %s", d.Id())
 	} else {
@@ -176,7 +218,15 @@ func ResourceConnectionUpdateInternal(d *schema.ResourceData, meta interface{})
 		}
 	}
 
-	// Stop related processors
+	// Stop related processors, only the ones running are started again
+	sourceRunning, err := client.ConnectionHandRunning(&connection.Component.Source)
+	if err != nil {
+		return fmt.Errorf("error retrieving source state: %s, %s", connection.Component.Source.Id, err)
+	}
+	destinationRunning, err := client.ConnectionHandRunning(&connection.Component.Destination)
+	if err != nil {
+		return fmt.Errorf("error retrieving destination state: %s, %s", connection.Component.Destination.Id, err)
+	}
 	err = client.StopConnectionHand(&connection.Component.Source)
 	if err != nil {
 		return fmt.Errorf("failed to stop source Processor: %s", connection.Component.Source.Id)
@@ -191,25 +241,32 @@ func ResourceConnectionUpdateInternal(d *schema.ResourceData, meta interface{})
 	if err != nil {
 		return fmt.Errorf("failed to parse Connection schema: %s", connectionId)
 	}
+	err = ConnectionWaitRemotePorts(client, connection, d.Timeout(schema.TimeoutUpdate))
+	if err != nil {
+		return err
+	}
 	err = client.UpdateConnection(connection)
 	if err != nil {
 		return fmt.Errorf("failed to update Connection: %s", connectionId)
 	}
 
 	// Start related processors
-
-	client.StartConnectionHand(&connection.Component.Source)
-	client.StartConnectionHand(&connection.Component.Destination)
+	if sourceRunning {
+		client.StartConnectionHand(&connection.Component.Source)
+	}
+	if destinationRunning {
+		client.StartConnectionHand(&connection.Component.Destination)
+	}
 
 	return ResourceConnectionRead(d, meta)
 }
 
 func ResourceConnectionDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*nifi.Client)
-	client.Lock.Lock()
+	unlock := ConnectionLock(client, d)
 	log.Printf("[INFO] Deleting Connection: %s...", d.Id())
 	err := ResourceConnectionDeleteInternal(d, meta)
-	defer client.Lock.Unlock()
+	defer unlock()
 	if err != nil {
 		log.Printf("[ERROR] Connection deletion failed: %s", d.Id())
 	} else {
@@ -234,22 +291,72 @@ func ResourceConnectionDeleteInternal(d *schema.ResourceData, meta interface{})
 	}
 	source := &connection.Component.Source
 	destination := &connection.Component.Destination
-	// Stop related processors if it is started
+	deletePolicy := d.Get("delete_policy").(string)
+
+	// Only the components running before the deletion are started again
+	sourceRunning, err := client.ConnectionHandRunning(source)
+	if err != nil {
+		return fmt.Errorf("error retrieving source state: %s, %s", source.Id, err)
+	}
+	destinationRunning, err := client.ConnectionHandRunning(destination)
+	if err != nil {
+		return fmt.Errorf("error retrieving destination state: %s, %s", destination.Id, err)
+	}
+	restoreSource := func(err error) error {
+		if !sourceRunning {
+			return err
+		}
+		startErr := client.StartConnectionHand(source)
+		if startErr != nil {
+			return fmt.Errorf("%s, failed to restart source %s: %s", err, source.Id, startErr)
+		}
+		return err
+	}
+
+	// Stop the source first so that the queue does not grow any more
 	err = client.StopConnectionHand(source)
 	if err != nil {
 		return fmt.Errorf("failed to stop source Processor: %s", connection.Component.Source.Id)
 	}
 
+	switch deletePolicy {
+	case ConnectionDeletePolicy_FAIL_IF_NOT_EMPTY:
+		status, err := client.GetConnectionStatus(connectionId)
+		if err != nil {
+			return restoreSource(fmt.Errorf("error retrieving Connection status: %s", connectionId))
+		}
+		snapshot := status.ConnectionStatus.AggregateSnapshot
+		if snapshot.FlowFilesQueued > 0 {
+			return restoreSource(fmt.Errorf("connection %s is not empty: %s FlowFiles (%s) queued",
+				connectionId, snapshot.QueuedCount, snapshot.QueuedSize))
+		}
+	case ConnectionDeletePolicy_DRAIN:
+		// Keep the destination running until it consumes everything in the queue
+		err = client.StartConnectionHand(destination)
+		if err != nil {
+			return restoreSource(fmt.Errorf("failed to start destination Processor: %s", connection.Component.Destination.Id))
+		}
+		err = client.DrainConnection(connection, d.Timeout(schema.TimeoutDelete))
+		if err != nil {
+			if !destinationRunning {
+				client.StopConnectionHand(destination)
+			}
+			return restoreSource(fmt.Errorf("error draining Connection: %s", err))
+		}
+	}
+
 	err = client.StopConnectionHand(destination)
 	if err != nil {
 		return fmt.Errorf("failed to stop destination Processor: %s", connection.Component.Destination.Id)
 	}
 
-	// Purge connection data
-	log.Printf("[INFO] Dropping connection data: %d", connection.Revision.Version)
-	err = client.DropConnectionData(connection)
-	if nil != err {
-		return fmt.Errorf("error purging Connection: %s", connectionId)
+	if deletePolicy == ConnectionDeletePolicy_DROP {
+		// Purge connection data
+		log.Printf("[INFO] Dropping connection data: %d", connection.Revision.Version)
+		err = client.DropConnectionData(connection)
+		if nil != err {
+			return fmt.Errorf("error purging Connection: %s", connectionId)
+		}
 	}
 
 	// Delete connection
@@ -264,8 +371,12 @@ func ResourceConnectionDeleteInternal(d *schema.ResourceData, meta interface{})
 	}
 
 	// Start related processors
-	client.StartConnectionHand(source)
-	client.StartConnectionHand(destination)
+	if sourceRunning {
+		client.StartConnectionHand(source)
+	}
+	if destinationRunning {
+		client.StartConnectionHand(destination)
+	}
 
 	d.SetId("")
 	return nil
@@ -289,6 +400,42 @@ func ResourceConnectionExists(d *schema.ResourceData, meta interface{}) (bool, e
 	return true, nil
 }
 
+// Start Helpers
+
+// ConnectionStartHands starts the components linked by a new connection. The transmission of remote ports is
+// owned by the remote process group (transmitting) and is left unchanged. Ports own their state, only the ones
+// waiting for a connection to be started are started (see PortApplyState).
+func ConnectionStartHands(client *nifi.Client, connection *nifi.Connection) {
+	for _, hand := range []*nifi.ConnectionHand{&connection.Component.Source, &connection.Component.Destination} {
+		if hand.IsRemotePort() {
+			continue
+		}
+		isPort := hand.Type == nifi.ConnectionHand_Type_INPUT_PORT || hand.Type == nifi.ConnectionHand_Type_OUTPUT_PORT
+		if isPort && !client.TakePortStartDeferred(hand.Id) {
+			continue
+		}
+		err := client.StartConnectionHand(hand)
+		if err != nil {
+			log.Printf("[INFO] Failed to start %s %s: %s", hand.Type, hand.Id, err)
+		}
+	}
+}
+
+// Remote Port Helpers
+
+func ConnectionWaitRemotePorts(client *nifi.Client, connection *nifi.Connection, timeout time.Duration) error {
+	for _, hand := range []*nifi.ConnectionHand{&connection.Component.Source, &connection.Component.Destination} {
+		if !hand.IsRemotePort() {
+			continue
+		}
+		err := client.WaitRemoteProcessGroupPort(hand.GroupId, hand.Id, hand.RemotePortType(), timeout)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Schema Helpers
 
 func ConnectionFromSchema(d *schema.ResourceData, connection *nifi.Connection) error {
@@ -307,7 +454,7 @@ func ConnectionFromSchema(d *schema.ResourceData, connection *nifi.Connection) e
 		return fmt.Errorf("exactly one component.source is required")
 	}
 	source := v[0].(map[string]interface{})
-	connection.Component.Source.Type = source["type"].(nifi.ConnectionHand_Type)
+	connection.Component.Source.Type = nifi.ConnectionHand_Type(source["type"].(string))
 	connection.Component.Source.Id = source["id"].(string)
 	connection.Component.Source.GroupId = source["group_id"].(string)
 
@@ -316,7 +463,7 @@ func ConnectionFromSchema(d *schema.ResourceData, connection *nifi.Connection) e
 		return fmt.Errorf("exactly one component.destination is required")
 	}
 	destination := v[0].(map[string]interface{})
-	connection.Component.Destination.Type = destination["type"].(nifi.ConnectionHand_Type)
+	connection.Component.Destination.Type = nifi.ConnectionHand_Type(destination["type"].(string))
 	connection.Component.Destination.Id = destination["id"].(string)
 	connection.Component.Destination.GroupId = destination["group_id"].(string)
 
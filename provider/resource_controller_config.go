@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"fmt"
+	"log"
+
+	nifi "github.com/glympse/terraform-provider-nifi/nifi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// The controller configuration always exists, nifi_controller_config manages its single instance.
+
+const ControllerConfigId = "controller-config"
+
+const (
+	ControllerConfigOnDestroy_KEEP             = "keep"
+	ControllerConfigOnDestroy_RESTORE_DEFAULTS = "restore_defaults"
+)
+
+func ResourceControllerConfig() *schema.Resource {
+	return &schema.Resource{
+		Create: ResourceControllerConfigCreate,
+		Read:   ResourceControllerConfigRead,
+		Update: ResourceControllerConfigUpdate,
+		Delete: ResourceControllerConfigDelete,
+		Importer: &schema.ResourceImporter{
+			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				d.SetId(ControllerConfigId)
+				d.Set("on_destroy", ControllerConfigOnDestroy_KEEP)
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			"revision": SchemaRevision(),
+			"max_timer_driven_thread_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"max_event_driven_thread_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"on_destroy": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  ControllerConfigOnDestroy_KEEP,
+				ValidateFunc: validation.StringInSlice([]string{
+					ControllerConfigOnDestroy_KEEP,
+					ControllerConfigOnDestroy_RESTORE_DEFAULTS,
+				}, false),
+			},
+		},
+	}
+}
+
+func ResourceControllerConfigCreate(d *schema.ResourceData, meta interface{}) error {
+	err := ResourceControllerConfigApply(d, meta)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(ControllerConfigId)
+	return ResourceControllerConfigRead(d, meta)
+}
+
+func ResourceControllerConfigRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*nifi.Client)
+	config, err := client.GetControllerConfig()
+	if err != nil {
+		return fmt.Errorf("Error retrieving Controller configuration: %s", err)
+	}
+
+	revision := []map[string]interface{}{{
+		"version": config.Revision.Version,
+	}}
+	d.Set("revision", revision)
+	d.Set("max_timer_driven_thread_count", config.Component.MaxTimerDrivenThreadCount)
+	d.Set("max_event_driven_thread_count", config.Component.MaxEventDrivenThreadCount)
+
+	return nil
+}
+
+func ResourceControllerConfigUpdate(d *schema.ResourceData, meta interface{}) error {
+	err := ResourceControllerConfigApply(d, meta)
+	if err != nil {
+		return err
+	}
+
+	return ResourceControllerConfigRead(d, meta)
+}
+
+func ResourceControllerConfigDelete(d *schema.ResourceData, meta interface{}) error {
+	if d.Get("on_destroy").(string) == ControllerConfigOnDestroy_RESTORE_DEFAULTS {
+		client := meta.(*nifi.Client)
+		config, err := client.GetControllerConfig()
+		if err != nil {
+			return fmt.Errorf("Error retrieving Controller configuration: %s", err)
+		}
+
+		log.Printf("[INFO] Restoring default Controller configuration")
+		config.Component.MaxTimerDrivenThreadCount = nifi.ControllerConfigDefaultMaxTimerDrivenThreadCount
+		config.Component.MaxEventDrivenThreadCount = nifi.ControllerConfigDefaultMaxEventDrivenThreadCount
+		err = client.UpdateControllerConfig(config)
+		if err != nil {
+			return fmt.Errorf("Failed to restore Controller configuration: %s", err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// ResourceControllerConfigApply updates the configured values on top of the latest revision,
+// values left out of the configuration are kept.
+func ResourceControllerConfigApply(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*nifi.Client)
+	config, err := client.GetControllerConfig()
+	if err != nil {
+		return fmt.Errorf("Error retrieving Controller configuration: %s", err)
+	}
+
+	if v, ok := d.GetOk("max_timer_driven_thread_count"); ok {
+		config.Component.MaxTimerDrivenThreadCount = v.(int)
+	}
+	if v, ok := d.GetOk("max_event_driven_thread_count"); ok {
+		config.Component.MaxEventDrivenThreadCount = v.(int)
+	}
+
+	err = client.UpdateControllerConfig(config)
+	if err != nil {
+		return fmt.Errorf("Failed to update Controller configuration: %s", err)
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package provider
 import (
 	"fmt"
 	"log"
+	"time"
 
 	nifi "github.com/glympse/terraform-provider-nifi/nifi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -10,15 +11,25 @@ import (
 
 func ResourceControllerService() *schema.Resource {
 	return &schema.Resource{
-		Create: ResourceControllerServiceCreate,
-		Read:   ResourceControllerServiceRead,
-		Update: ResourceControllerServiceUpdate,
-		Delete: ResourceControllerServiceDelete,
-		Exists: ResourceControllerServiceExists,
+		CreateContext: ComponentBulletinApply(ComponentValidationApply(ResourceControllerServiceCreate, ResourceControllerServiceRead)),
+		Read:          ResourceControllerServiceRead,
+		UpdateContext: ComponentBulletinApply(ComponentValidationApply(ResourceControllerServiceUpdate, ResourceControllerServiceRead)),
+		Delete:        ResourceControllerServiceDelete,
+		Exists:        ResourceControllerServiceExists,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+		},
+		CustomizeDiff: ComponentValidationCustomizeDiff(nifi.ComponentKind_CONTROLLER_SERVICE, "component.0.properties", ""),
 
 		Schema: map[string]*schema.Schema{
-			"parent_group_id": SchemaParentGroupId(),
-			"revision":        SchemaRevision(),
+			"parent_group_id":          SchemaParentGroupId(),
+			"revision":                 SchemaRevision(),
+			"validation_status":        SchemaValidationStatus(),
+			"validation_errors":        SchemaValidationErrors(),
+			"validation_severity":      SchemaValidationSeverity(),
+			"verify":                   SchemaVerify(),
+			"allow_dynamic_properties": SchemaAllowDynamicProperties(),
 			"component": {
 				Type:     schema.TypeList,
 				Required: true,
@@ -57,20 +68,29 @@ func ResourceControllerServiceCreate(d *schema.ResourceData, meta interface{}) e
 	}
 	parentGroupId := controllerService.Component.ParentGroupId
 
+	// Sensitive values are masked in NiFi responses, configuration is verified with the configured ones
+	properties := d.Get("component.0.properties").(map[string]interface{})
+
 	client := meta.(*nifi.Client)
 	err = client.CreateControllerService(&controllerService)
 	if err != nil {
 		return fmt.Errorf("Failed to create Controller Service")
 	}
 
+	d.SetId(controllerService.Component.Id)
+	d.Set("parent_group_id", parentGroupId)
+
+	err = ComponentVerify(client, d, nifi.ComponentKind_CONTROLLER_SERVICE, controllerService.Component.Id,
+		properties, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return fmt.Errorf("Failed to verify Controller Service: %s, %s", controllerService.Component.Id, err)
+	}
+
 	err = client.EnableControllerService(&controllerService)
 	if nil != err {
 		log.Printf("[INFO] Failed to enable Controller Service: %s", controllerService.Component.Id)
 	}
 
-	d.SetId(controllerService.Component.Id)
-	d.Set("parent_group_id", parentGroupId)
-
 	return ResourceControllerServiceRead(d, meta)
 }
 
@@ -116,11 +136,19 @@ func ResourceControllerServiceUpdate(d *schema.ResourceData, meta interface{}) e
 	if err != nil {
 		return fmt.Errorf("Failed to parse Controller Service schema: %s", controllerServiceId)
 	}
+	// Sensitive values are masked in the response
+	properties := d.Get("component.0.properties").(map[string]interface{})
 	err = client.UpdateControllerService(controllerService)
 	if err != nil {
 		return fmt.Errorf("Failed to update Controller Service: %s", controllerServiceId)
 	}
 
+	err = ComponentVerify(client, d, nifi.ComponentKind_CONTROLLER_SERVICE, controllerServiceId,
+		properties, d.Timeout(schema.TimeoutUpdate))
+	if err != nil {
+		return fmt.Errorf("Failed to verify Controller Service: %s, %s", controllerServiceId, err)
+	}
+
 	err = client.EnableControllerService(controllerService)
 	if nil != err {
 		log.Printf("[INFO] Failed to enable Controller Service: %s", controllerServiceId)
@@ -196,6 +224,7 @@ func ControllerServiceToSchema(d *schema.ResourceData, controllerService *nifi.C
 		"version": controllerService.Revision.Version,
 	}}
 	d.Set("revision", revision)
+	ComponentValidationToSchema(d, controllerService.Component.ValidationStatus, controllerService.Component.ValidationErrors)
 
 	component := []map[string]interface{}{{
 		"parent_group_id": d.Get("parent_group_id").(string),
@@ -10,11 +10,12 @@ import (
 
 func ResourceFunnel() *schema.Resource {
 	return &schema.Resource{
-		Create: ResourceFunnelCreate,
-		Read:   ResourceFunnelRead,
-		Update: ResourceFunnelUpdate,
-		Delete: ResourceFunnelDelete,
-		Exists: ResourceFunnelExists,
+		Create:        ResourceFunnelCreate,
+		Read:          ResourceFunnelRead,
+		Update:        ResourceFunnelUpdate,
+		Delete:        ResourceFunnelDelete,
+		Exists:        ResourceFunnelExists,
+		CustomizeDiff: ComponentMoveCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				return []*schema.ResourceData{d}, nil
@@ -84,9 +85,9 @@ func ResourceFunnelRead(d *schema.ResourceData, meta interface{}) error {
 
 func ResourceFunnelUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*nifi.Client)
-	client.Lock.Lock()
+	unlock := ComponentLock(client, d)
 	err := ResourceFunnelUpdateInternal(d, meta)
-	defer client.Lock.Unlock()
+	defer unlock()
 	if err == nil {
 		log.Printf("[INFO] Funnel updated: %s", d.Id())
 	} else {
@@ -100,12 +101,25 @@ func ResourceFunnelUpdateInternal(d *schema.ResourceData, meta interface{}) erro
 	// Refresh funnel details
 	client := meta.(*nifi.Client)
 	funnel, err := client.GetFunnel(funnelId)
-	if "not_found" == err.Error() {
-		d.SetId("")
-		return nil
+	if err != nil {
+		if "not_found" == err.Error() {
+			d.SetId("")
+			return nil
+		} else {
+			return fmt.Errorf("Error retrieving Funnel: %s", funnelId)
+		}
 	}
+
+	// Move funnel if its parent group has changed
+	moved, err := ComponentMove(client, d, nifi.SnippetComponentType_FUNNEL, funnel.Revision)
 	if err != nil {
-		return fmt.Errorf("Error retrieving Funnel: %s", funnelId)
+		return fmt.Errorf("Failed to move Funnel: %s, %s", funnelId, err)
+	}
+	if moved {
+		funnel, err = client.GetFunnel(funnelId)
+		if err != nil {
+			return fmt.Errorf("Error retrieving Funnel: %s", funnelId)
+		}
 	}
 
 	// Load funnel's desired state
@@ -120,15 +134,15 @@ func ResourceFunnelUpdateInternal(d *schema.ResourceData, meta interface{}) erro
 		return fmt.Errorf("Failed to update Funnel: %s", funnelId)
 	}
 
-	return ResourceGroupRead(d, meta)
+	return ResourceFunnelRead(d, meta)
 }
 
 func ResourceFunnelDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*nifi.Client)
 	log.Printf("[INFO] Deleting Funnel: %s...", d.Id())
-	client.Lock.Lock()
+	unlock := ComponentLock(client, d)
 	err := ResourceFunnelDeleteInternal(d, meta)
-	defer client.Lock.Unlock()
+	defer unlock()
 	if err == nil {
 		log.Printf("[INFO] Funnel deleted: %s", d.Id())
 	} else {
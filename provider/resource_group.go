@@ -95,9 +95,9 @@ func ResourceGroupRead(d *schema.ResourceData, meta interface{}) error {
 func ResourceGroupUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*nifi.Client)
 	log.Printf("[INFO] Updating Group: %s..., not implemented", d.Id())
-	client.Lock.Lock()
+	unlock := client.LockKeys(TenantsLockKey)
 	err := ResourceGroupUpdateInternal(d, meta)
-	defer client.Lock.Unlock()
+	defer unlock()
 	if err == nil {
 		log.Printf("[INFO] Group updated: %s", d.Id())
 	} else {
@@ -137,9 +137,9 @@ func ResourceGroupUpdateInternal(d *schema.ResourceData, meta interface{}) error
 func ResourceGroupDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*nifi.Client)
 	log.Printf("[INFO] Deleting Group: %s...", d.Id())
-	client.Lock.Lock()
+	unlock := client.LockKeys(TenantsLockKey)
 	err := ResourceGroupDeleteInternal(d, meta)
-	defer client.Lock.Unlock()
+	defer unlock()
 	log.Printf("[INFO] Group deleted: %s", d.Id())
 	return err
 }
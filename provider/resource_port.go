@@ -1,24 +1,34 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"log"
 
 	nifi "github.com/glympse/terraform-provider-nifi/nifi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func ResourcePort() *schema.Resource {
 	return &schema.Resource{
-		Create: ResourcePortCreate,
-		Read:   ResourcePortRead,
-		Update: ResourcePortUpdate,
-		Delete: ResourcePortDelete,
-		Exists: ResourcePortExists,
+		CreateContext: ComponentValidationApply(ResourcePortCreate, ResourcePortRead),
+		Read:          ResourcePortRead,
+		UpdateContext: ComponentValidationApply(ResourcePortUpdate, ResourcePortRead),
+		Delete:        ResourcePortDelete,
+		Exists:        ResourcePortExists,
+		CustomizeDiff: customdiff.All(
+			ComponentMoveCustomizeDiff,
+			PortAccessControlCustomizeDiff,
+		),
 
 		Schema: map[string]*schema.Schema{
-			"parent_group_id": SchemaParentGroupId(),
-			"revision":        SchemaRevision(),
+			"parent_group_id":     SchemaParentGroupId(),
+			"revision":            SchemaRevision(),
+			"validation_status":   SchemaValidationStatus(),
+			"validation_errors":   SchemaValidationErrors(),
+			"validation_severity": SchemaValidationSeverity(),
 			"component": {
 				Type:     schema.TypeList,
 				Required: true,
@@ -41,6 +51,37 @@ func ResourcePort() *schema.Resource {
 							Type:     schema.TypeString,
 							Optional: true,
 						},
+						"state": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  string(nifi.PortState_RUNNING),
+							ValidateFunc: validation.StringInSlice([]string{
+								string(nifi.PortState_RUNNING),
+								string(nifi.PortState_STOPPED),
+								string(nifi.PortState_DISABLED),
+							}, false),
+						},
+						"concurrently_schedulable_task_count": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"allow_remote_access": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Computed: true,
+						},
+						"user_access_control": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"group_access_control": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
 					},
 				},
 			},
@@ -69,12 +110,13 @@ func ResourcePortCreate(d *schema.ResourceData, meta interface{}) error {
 	d.SetId(port.Component.Id)
 	d.Set("parent_group_id", parentGroupId)
 
-	// Start processor upon creation, cannot start input port when there is no connection
-	if port.Component.PortType == "OUTPUT_PORT" {
-		err = client.StartPort(port)
-		if nil != err {
-			log.Printf("[INFO] Failed to start Port: %s ", port.Component.Id)
-		}
+	// Move port to its desired state, ports are created stopped
+	state := nifi.PortState(d.Get("component.0.state").(string))
+	err = PortApplyState(client, port, state)
+	if nil != err {
+		// Record the actual state so that the next plan retries the transition
+		ResourcePortRead(d, meta)
+		return fmt.Errorf("Failed to set Port %s state to %s: %s", port.Component.Id, state, err)
 	}
 
 	return ResourcePortRead(d, meta)
@@ -105,7 +147,7 @@ func ResourcePortRead(d *schema.ResourceData, meta interface{}) error {
 
 func ResourcePortUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*nifi.Client)
-	client.Lock.Lock()
+	unlock := ComponentLock(client, d)
 	log.Printf("[INFO] Updating Port: %s...", d.Id())
 	err := ResourcePortUpdateInternal(d, meta)
 	if err != nil {
@@ -113,7 +155,7 @@ func ResourcePortUpdate(d *schema.ResourceData, meta interface{}) error {
 	} else {
 		log.Printf("[INFO] Port updated: %s", d.Id())
 	}
-	defer client.Lock.Unlock()
+	defer unlock()
 	return err
 }
 
@@ -149,23 +191,35 @@ func ResourcePortUpdateInternal(d *schema.ResourceData, meta interface{}) error
 			log.Printf("[INFO] Port now in state: %s ", port.Component.State)
 		}
 	}
-	log.Printf("[INFO] ******0")
+
+	// Move port if its parent group has changed
+	moved, err := ComponentMove(client, d, nifi.SnippetComponentType(port.Component.PortType), port.Revision)
+	if err != nil {
+		return fmt.Errorf("Failed to move Port: %s, %s", portId, err)
+	}
+	if moved {
+		port, err = client.GetPort(portId, port.Component.PortType)
+		if err != nil {
+			return fmt.Errorf("Error retrieving Port: %s", portId)
+		}
+	}
 
 	err = PortFromSchema(d, port)
 	if err != nil {
 		return fmt.Errorf("Failed to parse Port schema: %s", portId)
 	}
-	log.Printf("[INFO] ******1")
 	err = client.UpdatePort(port)
 	if err != nil {
 		return fmt.Errorf("Failed to update Port: %s", err)
 	}
 
-	log.Printf("[INFO] ******2")
-	// Start processor again
-	err = client.StartPort(port)
+	// Move port to its desired state again
+	state := nifi.PortState(component["state"].(string))
+	err = PortApplyState(client, port, state)
 	if err != nil {
-		log.Printf("[INFO] Failed to start Port: %s", portId)
+		// Record the actual state so that the next plan retries the transition
+		ResourcePortRead(d, meta)
+		return fmt.Errorf("Failed to set Port %s state to %s: %s", portId, state, err)
 	}
 	log.Printf("[INFO] Done update port %s", portId)
 	return ResourcePortRead(d, meta)
@@ -173,7 +227,7 @@ func ResourcePortUpdateInternal(d *schema.ResourceData, meta interface{}) error
 
 func ResourcePortDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*nifi.Client)
-	client.Lock.Lock()
+	unlock := ComponentLock(client, d)
 	log.Printf("[INFO] Deleting Port: %s...", d.Id())
 	err := ResourcePortDeleteInternal(d, meta)
 	if err != nil {
@@ -181,7 +235,7 @@ func ResourcePortDelete(d *schema.ResourceData, meta interface{}) error {
 	} else {
 		log.Printf("[INFO] Failed to delete Port: %s", d.Id())
 	}
-	defer client.Lock.Unlock()
+	defer unlock()
 	return err
 }
 
@@ -193,7 +247,6 @@ func ResourcePortDeleteInternal(d *schema.ResourceData, meta interface{}) error
 	}
 	component := v[0].(map[string]interface{})
 	port_type := component["type"].(string)
-	log.Printf("Deleteing port ********************************%s, %s", port_type, portId)
 	// Refresh processor details
 	client := meta.(*nifi.Client)
 	port, err := client.GetPort(portId, nifi.PortType(port_type))
@@ -205,7 +258,6 @@ func ResourcePortDeleteInternal(d *schema.ResourceData, meta interface{}) error
 			return fmt.Errorf("Error retrieving Port: %s", portId)
 		}
 	}
-	log.Printf("Deleteing port ********************************1")
 	// Stop processor if it is currently running
 	if "STOPPED" != port.Component.State {
 		err = client.StopPort(port)
@@ -221,7 +273,6 @@ func ResourcePortDeleteInternal(d *schema.ResourceData, meta interface{}) error
 	}
 	//refresh version
 	// Delete processor
-	log.Printf("Deleteing port ********************************2")
 	err = client.DeletePort(port)
 	if err != nil {
 		return fmt.Errorf("Error deleting Port: %s", portId)
@@ -255,6 +306,43 @@ func ResourcePortExists(d *schema.ResourceData, meta interface{}) (bool, error)
 	return true, nil
 }
 
+// State Helpers
+
+// PortApplyState moves the port to its desired state. Ports without the connections they require are invalid
+// and cannot be started yet, they are kept stopped and the connection created next for them starts them.
+// A port connected later is started by the following apply, since it is reported as stopped once valid.
+func PortApplyState(client *nifi.Client, port *nifi.Port, state nifi.PortState) error {
+	deferred := state == nifi.PortState_RUNNING && port.ValidationStatus() != nifi.ValidationStatus_VALID
+	client.SetPortStartDeferred(port.Component.Id, deferred)
+	if deferred {
+		log.Printf("[INFO] Port %s is not valid yet, it is started once connected: %v",
+			port.Component.Id, port.Component.ValidationErrors)
+		state = nifi.PortState_STOPPED
+	}
+	return client.SetPortDesiredState(port, state)
+}
+
+// PortStatePending tells whether a port is kept stopped until a connection makes it valid.
+func PortStatePending(port *nifi.Port, state nifi.PortState) bool {
+	return state == nifi.PortState_RUNNING && port.Component.State == nifi.PortState_STOPPED &&
+		port.ValidationStatus() != nifi.ValidationStatus_VALID
+}
+
+// Access Control Helpers
+
+func PortAccessControlCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	key := "component.0.allow_remote_access"
+	if !d.NewValueKnown(key) || d.Get(key).(bool) {
+		return nil
+	}
+	for _, key := range []string{"user_access_control", "group_access_control"} {
+		if d.Get("component.0."+key).(*schema.Set).Len() > 0 {
+			return fmt.Errorf("component.0.%s requires component.0.allow_remote_access", key)
+		}
+	}
+	return nil
+}
+
 // Connection Helpers
 
 // Schema Helpers
@@ -268,6 +356,23 @@ func PortFromSchema(d *schema.ResourceData, port *nifi.Port) error {
 	port.Component.ParentGroupId = component["parent_group_id"].(string)
 	port.Component.Name = component["name"].(string)
 	port.Component.PortType = nifi.PortType(component["type"].(string))
+	port.Component.Comments = component["comments"].(string)
+	port.Component.ConcurrentlySchedulableTaskCount = component["concurrently_schedulable_task_count"].(int)
+
+	// Ports of the root group are always remote accessible, NiFi keeps its value when not configured
+	port.Component.AllowRemoteAccess = nil
+	if ComponentSettingConfigured(d, "allow_remote_access") {
+		allowRemoteAccess := component["allow_remote_access"].(bool)
+		port.Component.AllowRemoteAccess = &allowRemoteAccess
+	}
+	port.Component.UserAccessControl = []string{}
+	for _, v := range component["user_access_control"].(*schema.Set).List() {
+		port.Component.UserAccessControl = append(port.Component.UserAccessControl, v.(string))
+	}
+	port.Component.GroupAccessControl = []string{}
+	for _, v := range component["group_access_control"].(*schema.Set).List() {
+		port.Component.GroupAccessControl = append(port.Component.GroupAccessControl, v.(string))
+	}
 
 	v = component["position"].([]interface{})
 	if len(v) != 1 {
@@ -284,15 +389,44 @@ func PortToSchema(d *schema.ResourceData, port *nifi.Port) error {
 		"version": port.Revision.Version,
 	}}
 	d.Set("revision", revision)
+	ComponentValidationToSchema(d, port.ValidationStatus(), port.Component.ValidationErrors)
+
+	// Input ports waiting for a connection keep their desired state
+	state := port.Component.State
+	desired := nifi.PortState(d.Get("component.0.state").(string))
+	if PortStatePending(port, desired) {
+		state = desired
+	}
+
+	// Remote access is not reported by NiFi before 1.10
+	allowRemoteAccess := false
+	if port.Component.AllowRemoteAccess != nil {
+		allowRemoteAccess = *port.Component.AllowRemoteAccess
+	}
+
+	userAccessControl := []interface{}{}
+	for _, v := range port.Component.UserAccessControl {
+		userAccessControl = append(userAccessControl, v)
+	}
+	groupAccessControl := []interface{}{}
+	for _, v := range port.Component.GroupAccessControl {
+		groupAccessControl = append(groupAccessControl, v)
+	}
 
 	component := []map[string]interface{}{{
 		"parent_group_id": d.Get("parent_group_id").(string),
-		"name":            interface{}(port.Component.Name).(string),
-		"type":            interface{}(port.Component.PortType).(string),
+		"name":            port.Component.Name,
+		"type":            string(port.Component.PortType),
 		"position": []map[string]interface{}{{
 			"x": port.Component.Position.X,
 			"y": port.Component.Position.Y,
 		}},
+		"comments":                            port.Component.Comments,
+		"state":                               string(state),
+		"concurrently_schedulable_task_count": port.Component.ConcurrentlySchedulableTaskCount,
+		"allow_remote_access":                 allowRemoteAccess,
+		"user_access_control":                 userAccessControl,
+		"group_access_control":                groupAccessControl,
 	}}
 	d.Set("component", component)
 	return nil
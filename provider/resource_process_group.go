@@ -4,10 +4,13 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
+	"time"
 
 	nifi "github.com/glympse/terraform-provider-nifi/nifi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func ResourceProcessGroup() *schema.Resource {
@@ -16,9 +19,28 @@ func ResourceProcessGroup() *schema.Resource {
 		ReadContext:   ResourceProcessGroupRead,
 		UpdateContext: ResourceProcessGroupUpdate,
 		DeleteContext: ResourceProcessGroupDelete,
+		CustomizeDiff: ComponentMoveCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"parent_group_id": SchemaParentGroupId(),
 			"revision":        SchemaRevision(),
+			"force_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"force_destroy_queue_policy": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  ConnectionDeletePolicy_DROP,
+				ValidateFunc: validation.StringInSlice([]string{
+					ConnectionDeletePolicy_DROP,
+					ConnectionDeletePolicy_DRAIN,
+				}, false),
+			},
+			"rolling_update": SchemaRollingUpdate(),
 			"component": {
 				Type:     schema.TypeList,
 				Required: true,
@@ -33,6 +55,71 @@ func ResourceProcessGroup() *schema.Resource {
 							Required: true,
 						},
 						"position": SchemaPosition(),
+						"comments": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"flowfile_concurrency": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(nifi.ProcessGroupFlowFileConcurrency_UNBOUNDED),
+								string(nifi.ProcessGroupFlowFileConcurrency_SINGLE_FLOWFILE_PER_NODE),
+								string(nifi.ProcessGroupFlowFileConcurrency_SINGLE_BATCH_PER_NODE),
+							}, false),
+						},
+						"flowfile_outbound_policy": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(nifi.ProcessGroupFlowFileOutboundPolicy_STREAM_WHEN_AVAILABLE),
+								string(nifi.ProcessGroupFlowFileOutboundPolicy_BATCH_OUTPUT),
+							}, false),
+						},
+						"default_flowfile_expiration": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+						"default_back_pressure_object_threshold": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"default_back_pressure_data_size_threshold": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+						"log_file_suffix": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+						"execution_engine": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(nifi.ProcessGroupExecutionEngine_INHERITED),
+								string(nifi.ProcessGroupExecutionEngine_STANDARD),
+								string(nifi.ProcessGroupExecutionEngine_STATELESS),
+							}, false),
+						},
+						"stateless_max_concurrent_tasks": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"stateless_flow_timeout": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
 					},
 				},
 			},
@@ -82,7 +169,11 @@ func ResourceProcessGroupRead(ctx context.Context, d *schema.ResourceData, meta
 func ResourceProcessGroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	processGroupId := d.Id()
 
+	// Rolling updates stop the components of the group, moves change the parent groups
 	client := meta.(*nifi.Client)
+	unlock := client.LockKeys(processGroupId, d.Get("parent_group_id").(string), d.Get("component.0.parent_group_id").(string))
+	defer unlock()
+
 	processGroup, err := client.GetProcessGroup(processGroupId)
 	if err != nil {
 		if "not_found" == err.Error() {
@@ -93,14 +184,41 @@ func ResourceProcessGroupUpdate(ctx context.Context, d *schema.ResourceData, met
 		}
 	}
 
+	moved, err := ComponentMove(client, d, nifi.SnippetComponentType_PROCESS_GROUP, processGroup.Revision)
+	if err != nil {
+		return diag.Errorf("Failed to move Process Group: %s, %s", processGroupId, err)
+	}
+	if moved {
+		processGroup, err = client.GetProcessGroup(processGroupId)
+		if err != nil {
+			return diag.Errorf("error retrieving Process Group: %s", processGroupId)
+		}
+	}
+
 	err = ProcessGroupFromSchema(d, processGroup)
 	if err != nil {
 		return diag.Errorf("Failed to parse Process Group schema: %s", processGroupId)
 	}
 
-	err = client.UpdateProcessGroup(processGroup)
+	// Settings changing how the group runs its flow are applied while its components are stopped
+	apply := func() error {
+		return client.UpdateProcessGroup(processGroup)
+	}
+	if ProcessGroupRunSettingsChanged(d) {
+		err = ComponentRollingUpdate(client, d, processGroupId, nil, func() error {
+			// Refresh the revision, stopping the components may have modified it
+			current, err := client.GetProcessGroup(processGroupId)
+			if err != nil {
+				return err
+			}
+			processGroup.Revision = current.Revision
+			return apply()
+		})
+	} else {
+		err = apply()
+	}
 	if err != nil {
-		return diag.Errorf("Failed to update Process Group: %s", processGroupId)
+		return diag.Errorf("Failed to update Process Group: %s, %s", processGroupId, err)
 	}
 
 	return ResourceProcessGroupRead(ctx, d, meta)
@@ -110,7 +228,20 @@ func ResourceProcessGroupDelete(ctx context.Context, d *schema.ResourceData, met
 	processGroupId := d.Id()
 	log.Printf("[INFO] Deleting Process Group: %s", processGroupId)
 
+	// Emptying the group stops, purges and deletes the components of the group and of its nested groups
 	client := meta.(*nifi.Client)
+	keys := []string{processGroupId, d.Get("parent_group_id").(string), d.Get("component.0.parent_group_id").(string)}
+	if d.Get("force_destroy").(bool) {
+		flows, err := client.GetProcessGroupFlows(processGroupId)
+		if err == nil {
+			for _, flow := range flows {
+				keys = append(keys, flow.ProcessGroupFlow.Id)
+			}
+		}
+	}
+	unlock := client.LockKeys(keys...)
+	defer unlock()
+
 	processGroup, err := client.GetProcessGroup(processGroupId)
 	if nil != err {
 		if "not_found" == err.Error() {
@@ -121,6 +252,18 @@ func ResourceProcessGroupDelete(ctx context.Context, d *schema.ResourceData, met
 		}
 	}
 
+	if d.Get("force_destroy").(bool) {
+		err = ProcessGroupEmpty(client, processGroupId, d.Get("force_destroy_queue_policy").(string), d.Timeout(schema.TimeoutDelete))
+		if err != nil {
+			return diag.Errorf("Failed to empty Process Group: %s, %s", processGroupId, err)
+		}
+		// Refresh the revision, emptying the group has modified it
+		processGroup, err = client.GetProcessGroup(processGroupId)
+		if err != nil {
+			return diag.Errorf("error retrieving Process Group: %s", processGroupId)
+		}
+	}
+
 	err = client.DeleteProcessGroup(processGroup)
 	if err != nil {
 		return diag.Errorf("error deleting Process Group: %s", processGroupId)
@@ -148,6 +291,222 @@ func ResourceProcessGroupExists(d *schema.ResourceData, meta interface{}) (bool,
 	return true, nil
 }
 
+// Rolling Update Helpers
+
+var ProcessGroupRunSettings = []string{
+	"flowfile_concurrency",
+	"flowfile_outbound_policy",
+	"execution_engine",
+	"stateless_max_concurrent_tasks",
+	"stateless_flow_timeout",
+}
+
+func ProcessGroupRunSettingsChanged(d *schema.ResourceData) bool {
+	for _, key := range ProcessGroupRunSettings {
+		if d.HasChange("component.0." + key) {
+			return true
+		}
+	}
+	return false
+}
+
+// Force Destroy Helpers
+
+// ProcessGroupEmpty brings the group and its descendants to a state NiFi accepts for deletion: components are stopped,
+// queues are drained or purged, controller services are disabled and labels and templates are removed.
+func ProcessGroupEmpty(client *nifi.Client, processGroupId string, queuePolicy string, timeout time.Duration) error {
+	flows, err := client.GetProcessGroupFlows(processGroupId)
+	if err != nil {
+		return fmt.Errorf("error retrieving Process Group flow: %s", err)
+	}
+
+	// Remote process groups keep feeding the flow until their transmission is stopped
+	err = ProcessGroupStopRemoteTransmission(client, flows)
+	if err != nil {
+		return err
+	}
+
+	if queuePolicy == ConnectionDeletePolicy_DRAIN {
+		log.Printf("[INFO] Force destroying Process Group %s: stopping sources and draining queues", processGroupId)
+		err = ProcessGroupStopSources(client, flows)
+		if err != nil {
+			return err
+		}
+		err = ProcessGroupStartDestinations(client, processGroupId, flows)
+		if err != nil {
+			return err
+		}
+		connectionIds := []string{}
+		for _, flow := range flows {
+			for _, connection := range flow.ProcessGroupFlow.Flow.Connections {
+				connectionIds = append(connectionIds, connection.Component.Id)
+			}
+		}
+		err = client.WaitConnectionsEmpty(connectionIds, timeout)
+		if err != nil {
+			return fmt.Errorf("queues were not drained: %s", err)
+		}
+	}
+
+	log.Printf("[INFO] Force destroying Process Group %s: stopping components", processGroupId)
+	err = client.ScheduleProcessGroupComponents(processGroupId, nifi.ProcessGroupScheduleState_STOPPED)
+	if err != nil {
+		return fmt.Errorf("failed to stop components: %s", err)
+	}
+	err = client.WaitUtil(timeout, func(c *nifi.Client) bool {
+		status, err := c.GetProcessGroupStatus(processGroupId)
+		if err != nil {
+			return false
+		}
+		return status.ProcessGroupStatus.AggregateSnapshot.ActiveThreadCount == 0
+	})
+	if err != nil {
+		return fmt.Errorf("components still have active threads after %s", timeout)
+	}
+
+	if queuePolicy == ConnectionDeletePolicy_DROP {
+		log.Printf("[INFO] Force destroying Process Group %s: purging queues", processGroupId)
+		for _, flow := range flows {
+			for _, connection := range flow.ProcessGroupFlow.Flow.Connections {
+				err = client.DropConnectionData(&connection)
+				if err != nil {
+					return fmt.Errorf("error purging Connection: %s", connection.Component.Id)
+				}
+			}
+		}
+	}
+
+	log.Printf("[INFO] Force destroying Process Group %s: disabling controller services", processGroupId)
+	err = client.SetProcessGroupControllerServicesState(processGroupId, nifi.ControllerServiceState_DISABLED)
+	if err != nil {
+		return fmt.Errorf("failed to disable controller services: %s", err)
+	}
+
+	log.Printf("[INFO] Force destroying Process Group %s: removing labels and templates", processGroupId)
+	groupIds := map[string]bool{}
+	for _, flow := range flows {
+		groupIds[flow.ProcessGroupFlow.Id] = true
+		for _, label := range flow.ProcessGroupFlow.Flow.Labels {
+			err = client.DeleteLabel(&label)
+			if err != nil {
+				return fmt.Errorf("error deleting Label: %s", label.Component.Id)
+			}
+		}
+	}
+	templates, err := client.GetTemplates()
+	if err != nil {
+		// Templates are not available in every NiFi version
+		log.Printf("[WARN] Failed to list templates: %s", err)
+	} else {
+		for _, template := range templates.Templates {
+			if !groupIds[template.Template.GroupId] {
+				continue
+			}
+			err = client.DeleteTemplate(template.Id)
+			if err != nil {
+				return fmt.Errorf("error deleting Template: %s", template.Id)
+			}
+		}
+	}
+
+	return nil
+}
+
+func ProcessGroupStopRemoteTransmission(client *nifi.Client, flows []nifi.ProcessGroupFlow) error {
+	for _, flow := range flows {
+		for _, remoteProcessGroup := range flow.ProcessGroupFlow.Flow.RemoteProcessGroups {
+			if remoteProcessGroup.Component.Transmitting == nil || !*remoteProcessGroup.Component.Transmitting {
+				continue
+			}
+			err := client.StopRemoteProcessGroupTransmission(&remoteProcessGroup)
+			if err != nil {
+				return fmt.Errorf("failed to stop Remote Process Group transmission: %s", remoteProcessGroup.Component.Id)
+			}
+		}
+	}
+	return nil
+}
+
+// ProcessGroupStopSources stops the processors without incoming connections and the input ports of the top group,
+// so that the rest of the flow can drain the queues.
+func ProcessGroupStopSources(client *nifi.Client, flows []nifi.ProcessGroupFlow) error {
+	destinations := map[string]bool{}
+	for _, flow := range flows {
+		for _, connection := range flow.ProcessGroupFlow.Flow.Connections {
+			if connection.Component.Source.Id != connection.Component.Destination.Id {
+				destinations[connection.Component.Destination.Id] = true
+			}
+		}
+	}
+
+	for _, flow := range flows {
+		for _, processor := range flow.ProcessGroupFlow.Flow.Processors {
+			if destinations[processor.Component.Id] || "RUNNING" != processor.Component.State {
+				continue
+			}
+			err := client.StopProcessor(&processor)
+			if err != nil {
+				return fmt.Errorf("failed to stop Processor: %s", processor.Component.Id)
+			}
+		}
+	}
+
+	for _, port := range flows[0].ProcessGroupFlow.Flow.InputPorts {
+		if nifi.PortState_RUNNING != port.Component.State {
+			continue
+		}
+		err := client.StopPort(&port)
+		if err != nil {
+			return fmt.Errorf("failed to stop Port: %s", port.Component.Id)
+		}
+	}
+
+	return nil
+}
+
+// ProcessGroupStartDestinations starts the stopped destinations of the group connections, as a drained
+// nifi_connection does. It fails listing the non empty connections which cannot be drained: those feeding remote
+// process groups or the output ports of the destroyed group, looping on a stopped source or whose destination
+// cannot be started.
+func ProcessGroupStartDestinations(client *nifi.Client, processGroupId string, flows []nifi.ProcessGroupFlow) error {
+	blocked := []string{}
+	for _, flow := range flows {
+		for _, connection := range flow.ProcessGroupFlow.Flow.Connections {
+			reason := ""
+			destination := connection.Component.Destination
+			switch {
+			case destination.IsRemotePort():
+				reason = "remote process group transmission is stopped"
+			case destination.Type == nifi.ConnectionHand_Type_OUTPUT_PORT && destination.GroupId == processGroupId:
+				reason = "output port of the destroyed group"
+			default:
+				running, err := client.ConnectionHandRunning(&destination)
+				if err != nil {
+					reason = err.Error()
+				} else if running || destination.Type == nifi.ConnectionHand_Type_FUNNEL {
+					continue
+				} else if connection.Component.Source.Id == destination.Id {
+					reason = "loops on a stopped source"
+				} else if err = client.StartConnectionHand(&destination); err != nil {
+					reason = fmt.Sprintf("failed to start %s %s: %s", destination.Type, destination.Id, err)
+				} else {
+					continue
+				}
+			}
+
+			status, err := client.GetConnectionStatus(connection.Component.Id)
+			if err == nil && status.ConnectionStatus.AggregateSnapshot.FlowFilesQueued == 0 {
+				continue
+			}
+			blocked = append(blocked, fmt.Sprintf("%s (%s)", connection.Component.Id, reason))
+		}
+	}
+	if len(blocked) > 0 {
+		return fmt.Errorf("queues cannot be drained: %s", strings.Join(blocked, ", "))
+	}
+	return nil
+}
+
 // Schema Helpers
 
 func ProcessGroupFromSchema(d *schema.ResourceData, processGroup *nifi.ProcessGroup) error {
@@ -169,6 +528,39 @@ func ProcessGroupFromSchema(d *schema.ResourceData, processGroup *nifi.ProcessGr
 	processGroup.Component.Position.X = position["x"].(float64)
 	processGroup.Component.Position.Y = position["y"].(float64)
 
+	processGroup.Component.Comments = component["comments"].(string)
+
+	// Settings depending on the NiFi version are only sent when configured, NiFi keeps its value otherwise
+	if ComponentSettingConfigured(d, "flowfile_concurrency") {
+		processGroup.Component.FlowFileConcurrency = nifi.ProcessGroupFlowFileConcurrency(component["flowfile_concurrency"].(string))
+	}
+	if ComponentSettingConfigured(d, "flowfile_outbound_policy") {
+		processGroup.Component.FlowFileOutboundPolicy = nifi.ProcessGroupFlowFileOutboundPolicy(component["flowfile_outbound_policy"].(string))
+	}
+	if ComponentSettingConfigured(d, "default_flowfile_expiration") {
+		processGroup.Component.DefaultFlowFileExpiration = component["default_flowfile_expiration"].(string)
+	}
+	if ComponentSettingConfigured(d, "default_back_pressure_object_threshold") {
+		threshold := component["default_back_pressure_object_threshold"].(int)
+		processGroup.Component.DefaultBackPressureObjectThreshold = &threshold
+	}
+	if ComponentSettingConfigured(d, "default_back_pressure_data_size_threshold") {
+		processGroup.Component.DefaultBackPressureDataSizeThreshold = component["default_back_pressure_data_size_threshold"].(string)
+	}
+	if ComponentSettingConfigured(d, "log_file_suffix") {
+		logFileSuffix := component["log_file_suffix"].(string)
+		processGroup.Component.LogFileSuffix = &logFileSuffix
+	}
+	if ComponentSettingConfigured(d, "execution_engine") {
+		processGroup.Component.ExecutionEngine = nifi.ProcessGroupExecutionEngine(component["execution_engine"].(string))
+	}
+	if ComponentSettingConfigured(d, "stateless_max_concurrent_tasks") {
+		processGroup.Component.MaxConcurrentTasks = component["stateless_max_concurrent_tasks"].(int)
+	}
+	if ComponentSettingConfigured(d, "stateless_flow_timeout") {
+		processGroup.Component.StatelessFlowTimeout = component["stateless_flow_timeout"].(string)
+	}
+
 	return nil
 }
 
@@ -178,6 +570,15 @@ func ProcessGroupToSchema(d *schema.ResourceData, processGroup *nifi.ProcessGrou
 	}}
 	d.Set("revision", revision)
 
+	logFileSuffix := ""
+	if processGroup.Component.LogFileSuffix != nil {
+		logFileSuffix = *processGroup.Component.LogFileSuffix
+	}
+	backPressureObjectThreshold := 0
+	if processGroup.Component.DefaultBackPressureObjectThreshold != nil {
+		backPressureObjectThreshold = *processGroup.Component.DefaultBackPressureObjectThreshold
+	}
+
 	component := []map[string]interface{}{{
 		"parent_group_id": d.Get("parent_group_id").(string),
 		"name":            processGroup.Component.Name,
@@ -185,6 +586,16 @@ func ProcessGroupToSchema(d *schema.ResourceData, processGroup *nifi.ProcessGrou
 			"x": processGroup.Component.Position.X,
 			"y": processGroup.Component.Position.Y,
 		}},
+		"comments":                                  processGroup.Component.Comments,
+		"flowfile_concurrency":                      string(processGroup.Component.FlowFileConcurrency),
+		"flowfile_outbound_policy":                  string(processGroup.Component.FlowFileOutboundPolicy),
+		"default_flowfile_expiration":               processGroup.Component.DefaultFlowFileExpiration,
+		"default_back_pressure_object_threshold":    backPressureObjectThreshold,
+		"default_back_pressure_data_size_threshold": processGroup.Component.DefaultBackPressureDataSizeThreshold,
+		"log_file_suffix":                           logFileSuffix,
+		"execution_engine":                          string(processGroup.Component.ExecutionEngine),
+		"stateless_max_concurrent_tasks":            processGroup.Component.MaxConcurrentTasks,
+		"stateless_flow_timeout":                    processGroup.Component.StatelessFlowTimeout,
 	}}
 	d.Set("component", component)
 
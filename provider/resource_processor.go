@@ -3,22 +3,61 @@ package provider
 import (
 	"fmt"
 	"log"
+	"time"
 
 	nifi "github.com/glympse/terraform-provider-nifi/nifi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func ResourceProcessor() *schema.Resource {
 	return &schema.Resource{
-		Create: ResourceProcessorCreate,
-		Read:   ResourceProcessorRead,
-		Update: ResourceProcessorUpdate,
-		Delete: ResourceProcessorDelete,
-		Exists: ResourceProcessorExists,
+		CreateContext: ComponentBulletinApply(ComponentValidationApply(ResourceProcessorCreate, ResourceProcessorRead)),
+		Read:          ResourceProcessorRead,
+		UpdateContext: ComponentBulletinApply(ComponentValidationApply(ResourceProcessorUpdate, ResourceProcessorRead)),
+		Delete:        ResourceProcessorDelete,
+		Exists:        ResourceProcessorExists,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+		},
+		CustomizeDiff: customdiff.All(
+			ComponentMoveCustomizeDiff,
+			ComponentValidationCustomizeDiff(nifi.ComponentKind_PROCESSOR,
+				"component.0.config.0.properties", "component.0.config.0.auto_terminated_relationships"),
+		),
 
 		Schema: map[string]*schema.Schema{
-			"parent_group_id": SchemaParentGroupId(),
-			"revision":        SchemaRevision(),
+			"parent_group_id":          SchemaParentGroupId(),
+			"revision":                 SchemaRevision(),
+			"validation_status":        SchemaValidationStatus(),
+			"validation_errors":        SchemaValidationErrors(),
+			"validation_severity":      SchemaValidationSeverity(),
+			"verify":                   SchemaVerify(),
+			"allow_dynamic_properties": SchemaAllowDynamicProperties(),
+			"clear_state_trigger": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"stop_timeout": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      nifi.ProcessorDefaultStopTimeout.String(),
+				ValidateFunc: ValidatePositiveDuration,
+			},
+			"terminate_on_stop_timeout": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"connection_delete_policy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      ConnectionDeletePolicy_DROP,
+				ValidateFunc: ValidateConnectionDeletePolicy,
+			},
+			"rolling_update": SchemaRollingUpdate(),
 			"component": {
 				Type:     schema.TypeList,
 				Required: true,
@@ -51,6 +90,10 @@ func ResourceProcessor() *schema.Resource {
 										Type:     schema.TypeString,
 										Optional: true,
 										Default:  "TIMER_DRIVEN",
+										ValidateFunc: validation.StringInSlice([]string{
+											string(nifi.SchedulingStrategy_TIMER_DRIVEN),
+											string(nifi.SchedulingStrategy_CRON_DRIVEN),
+										}, false),
 									},
 									"scheduling_period": {
 										Type:     schema.TypeString,
@@ -61,6 +104,10 @@ func ResourceProcessor() *schema.Resource {
 										Type:     schema.TypeString,
 										Optional: true,
 										Default:  "ALL",
+										ValidateFunc: validation.StringInSlice([]string{
+											string(nifi.ExecutionNode_ALL),
+											string(nifi.ExecutionNode_PRIMARY),
+										}, false),
 									},
 									"properties": {
 										Type:     schema.TypeMap,
@@ -91,6 +138,9 @@ func ResourceProcessorCreate(d *schema.ResourceData, meta interface{}) error {
 	}
 	parentGroupId := processor.Component.ParentGroupId
 
+	// Sensitive values are masked in NiFi responses, configuration is verified with the configured ones
+	properties := d.Get("component.0.config.0.properties").(map[string]interface{})
+
 	// Create processor
 	client := meta.(*nifi.Client)
 	err = client.CreateProcessor(processor)
@@ -98,16 +148,23 @@ func ResourceProcessorCreate(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("Failed to create Processor")
 	}
 
+	// Indicate successful creation
+	d.SetId(processor.Component.Id)
+	d.Set("parent_group_id", parentGroupId)
+
+	// Verify configuration before the processor is started
+	err = ComponentVerify(client, d, nifi.ComponentKind_PROCESSOR, processor.Component.Id,
+		properties, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return fmt.Errorf("Failed to verify Processor: %s, %s", processor.Component.Id, err)
+	}
+
 	// Start processor upon creation
 	err = client.StartProcessor(processor)
 	if nil != err {
 		log.Printf("[INFO] Failed to start Processor: %s ", processor.Component.Id)
 	}
 
-	// Indicate successful creation
-	d.SetId(processor.Component.Id)
-	d.Set("parent_group_id", parentGroupId)
-
 	return ResourceProcessorRead(d, meta)
 }
 
@@ -130,11 +187,11 @@ func ResourceProcessorRead(d *schema.ResourceData, meta interface{}) error {
 
 func ResourceProcessorUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*nifi.Client)
-	client.Lock.Lock()
+	unlock := ComponentLock(client, d)
 	log.Printf("[INFO] Updating Processor: %s...", d.Id())
 	err := ResourceProcessorUpdateInternal(d, meta)
 	log.Printf("[INFO] Processor updated: %s", d.Id())
-	defer client.Lock.Unlock()
+	defer unlock()
 	return err
 }
 
@@ -153,11 +210,48 @@ func ResourceProcessorUpdateInternal(d *schema.ResourceData, meta interface{}) e
 		}
 	}
 
-	// Stop processor if it is currently running
-	if "RUNNING" == processor.Component.State {
-		err = client.StopProcessor(processor)
+	// Rolling updates stop and start the processor along with the components upstream
+	rolling := ComponentRollingUpdateEnabled(d)
+	changed := []nifi.ConnectionHand{{
+		Type:    nifi.ConnectionHand_Type_PROCESSOR,
+		Id:      processorId,
+		GroupId: processor.Component.ParentGroupId,
+	}}
+	err = ComponentRollingUpdate(client, d, processor.Component.ParentGroupId, changed, func() error {
+		return ProcessorApplyUpdate(client, d, !rolling)
+	})
+	if err != nil {
+		return err
+	}
+
+	return ResourceProcessorRead(d, meta)
+}
+
+// ProcessorApplyUpdate stops the processor, applies the configuration and starts the processor again when start is set.
+func ProcessorApplyUpdate(client *nifi.Client, d *schema.ResourceData, start bool) error {
+	processorId := d.Id()
+	processor, err := client.GetProcessor(processorId)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Processor: %s", processorId)
+	}
+
+	// Stop processor if it is currently running, or wait for the threads left by a stop without wait
+	if "RUNNING" == processor.Component.State || processor.ActiveThreadCount() > 0 {
+		err = ProcessorStop(client, d, processor)
+		if err != nil {
+			return fmt.Errorf("Failed to stop Processor: %s, %s", processorId, err)
+		}
+	}
+
+	// Move processor if its parent group has changed
+	moved, err := ComponentMove(client, d, nifi.SnippetComponentType_PROCESSOR, processor.Revision)
+	if err != nil {
+		return fmt.Errorf("Failed to move Processor: %s, %s", processorId, err)
+	}
+	if moved {
+		processor, err = client.GetProcessor(processorId)
 		if err != nil {
-			return fmt.Errorf("Failed to stop Processor: %s", processorId)
+			return fmt.Errorf("Error retrieving Processor: %s", processorId)
 		}
 	}
 
@@ -169,33 +263,52 @@ func ResourceProcessorUpdateInternal(d *schema.ResourceData, meta interface{}) e
 
 	// Compare new list of auto-terminated connections against the list of processor's existing connections.
 	// It is not possible to auto-terminate a relationship if an existing connection declares this relationship type.
-	err = ProcessorRemoveOverlappingConnections(client, processor)
+	err = ProcessorRemoveOverlappingConnections(client, d, processor)
 	if nil != err {
-		return fmt.Errorf("Failed to cleanup connections for Processor: %s", processorId)
+		return fmt.Errorf("Failed to cleanup connections for Processor: %s, %s", processorId, err)
 	}
 
-	// Update processor
+	// Update processor, sensitive values are masked in the response
+	properties := d.Get("component.0.config.0.properties").(map[string]interface{})
 	err = client.UpdateProcessor(processor)
 	if err != nil {
 		return fmt.Errorf("Failed to update Processor: %s", processorId)
 	}
 
-	// Start processor again
-	err = client.StartProcessor(processor)
+	// Clear stored state while the processor is stopped
+	if d.HasChange("clear_state_trigger") {
+		log.Printf("[INFO] Clearing Processor state: %s", processorId)
+		err = client.ClearComponentState(nifi.ComponentKind_PROCESSOR, processorId)
+		if err != nil {
+			return fmt.Errorf("Failed to clear Processor state: %s, %s", processorId, err)
+		}
+	}
+
+	// Verify configuration before the processor is started again
+	err = ComponentVerify(client, d, nifi.ComponentKind_PROCESSOR, processorId,
+		properties, d.Timeout(schema.TimeoutUpdate))
 	if err != nil {
-		log.Printf("[INFO] Failed to start Processor: %s", processorId)
+		return fmt.Errorf("Failed to verify Processor: %s, %s", processorId, err)
 	}
 
-	return ResourceProcessorRead(d, meta)
+	// Start processor again
+	if start {
+		err = client.StartProcessor(processor)
+		if err != nil {
+			log.Printf("[INFO] Failed to start Processor: %s", processorId)
+		}
+	}
+
+	return nil
 }
 
 func ResourceProcessorDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*nifi.Client)
-	client.Lock.Lock()
+	unlock := ComponentLock(client, d)
 	log.Printf("[INFO] Deleting Processor: %s...", d.Id())
 	err := ResourceProcessorDeleteInternal(d, meta)
 	log.Printf("[INFO] Processor deleted: %s", d.Id())
-	defer client.Lock.Unlock()
+	defer unlock()
 	return err
 }
 
@@ -214,11 +327,11 @@ func ResourceProcessorDeleteInternal(d *schema.ResourceData, meta interface{}) e
 		}
 	}
 
-	// Stop processor if it is currently running
-	if "RUNNING" == processor.Component.State {
-		err = client.StopProcessor(processor)
+	// Stop processor if it is currently running, or wait for the threads left by a stop without wait
+	if "RUNNING" == processor.Component.State || processor.ActiveThreadCount() > 0 {
+		err = ProcessorStop(client, d, processor)
 		if err != nil {
-			return fmt.Errorf("Failed to stop Processor: %s", processorId)
+			return fmt.Errorf("Failed to stop Processor: %s, %s", processorId, err)
 		}
 	}
 
@@ -252,7 +365,9 @@ func ResourceProcessorExists(d *schema.ResourceData, meta interface{}) (bool, er
 
 // Connection Helpers
 
-func ProcessorRemoveOverlappingConnections(client *nifi.Client, processor *nifi.Processor) error {
+// ProcessorRemoveOverlappingConnections removes the relationships auto-terminated by the processor from its
+// outgoing connections. Connections left without relationships are deleted according to connection_delete_policy.
+func ProcessorRemoveOverlappingConnections(client *nifi.Client, d *schema.ResourceData, processor *nifi.Processor) error {
 	// Build a set of processor's auto-terminated relationships
 	terminatedRelationships := map[string]bool{}
 	for _, v := range processor.Component.Config.AutoTerminatedRelationships {
@@ -289,15 +404,8 @@ func ProcessorRemoveOverlappingConnections(client *nifi.Client, processor *nifi.
 	}
 
 	// Remove overlaps
+	deletePolicy := d.Get("connection_delete_policy").(string)
 	for _, connection := range overlappingConnections {
-		// Stop destination processor
-		//err = ConnectionStopProcessor(client, connection.Component.Destination.Id)
-		err = client.StopConnectionHand(&connection.Component.Destination)
-		if nil != err {
-			log.Printf("[INFO] Failed to stop Processor: %s", connection.Component.Destination.Id)
-			continue
-		}
-
 		// Prepare the list of relationships connection is allowed to keep
 		filteredRelationships := connection.Component.SelectedRelationships[:0]
 		for _, relationship := range connection.Component.SelectedRelationships {
@@ -306,6 +414,26 @@ func ProcessorRemoveOverlappingConnections(client *nifi.Client, processor *nifi.
 			}
 		}
 
+		destination := &connection.Component.Destination
+		destinationRunning, err := client.ConnectionHandRunning(destination)
+		if nil != err {
+			return fmt.Errorf("Failed to retrieve destination state: %s, %s", destination.Id, err)
+		}
+
+		if len(filteredRelationships) == 0 {
+			err = ProcessorEmptyConnection(client, &connection, deletePolicy, destinationRunning, d.Timeout(schema.TimeoutUpdate))
+			if nil != err {
+				return err
+			}
+		}
+
+		// Stop destination processor
+		err = client.StopConnectionHand(destination)
+		if nil != err {
+			log.Printf("[INFO] Failed to stop Processor: %s", destination.Id)
+			continue
+		}
+
 		// Update/remove connection
 		if len(filteredRelationships) > 0 {
 			err = client.UpdateConnection(&connection)
@@ -313,30 +441,80 @@ func ProcessorRemoveOverlappingConnections(client *nifi.Client, processor *nifi.
 				log.Printf("[INFO] Failed to update Connection: %s", connection.Component.Id)
 			}
 		} else {
-			// Purge connection data
-			err = client.DropConnectionData(&connection)
+			// Remove the connection, refreshed since emptying it changes its revision
+			current, err := client.GetConnection(connection.Component.Id)
+			if nil == err {
+				err = client.DeleteConnection(current)
+			}
 			if nil != err {
-				log.Printf("[INFO] Error purging Connection: %s", connection.Component.Id)
+				log.Printf("[INFO] Failed to delete Connection: %s", connection.Component.Id)
 			}
+		}
 
-			// Remove the connection
-			err = client.DeleteConnection(&connection)
+		// Start destination processor again if it was running
+		if destinationRunning {
+			err = client.StartConnectionHand(destination)
 			if nil != err {
-				log.Printf("[INFO] Failed to delete Connection: %s", connection.Component.Id)
+				log.Printf("[INFO] Failed to start Processor: %s", destination.Id)
 			}
 		}
+	}
 
-		// Start destination processor
-		//err = ConnectionStartProcessor(client, connection.Component.Destination.Id)
-		err = client.StartConnectionHand(&connection.Component.Destination)
+	return nil
+}
+
+// ProcessorEmptyConnection empties a connection about to be removed according to the delete policy. The processor,
+// which is the source of the connection, is stopped. The destination is started to drain the queue when it is not
+// running already, and stopped again if the queue cannot be drained.
+func ProcessorEmptyConnection(client *nifi.Client, connection *nifi.Connection, deletePolicy string, destinationRunning bool, timeout time.Duration) error {
+	connectionId := connection.Component.Id
+	destination := &connection.Component.Destination
+	switch deletePolicy {
+	case ConnectionDeletePolicy_FAIL_IF_NOT_EMPTY:
+		status, err := client.GetConnectionStatus(connectionId)
+		if nil != err {
+			return fmt.Errorf("Error retrieving Connection status: %s", connectionId)
+		}
+		snapshot := status.ConnectionStatus.AggregateSnapshot
+		if snapshot.FlowFilesQueued > 0 {
+			return fmt.Errorf("Connection %s is not empty: %s FlowFiles (%s) queued",
+				connectionId, snapshot.QueuedCount, snapshot.QueuedSize)
+		}
+	case ConnectionDeletePolicy_DRAIN:
+		if !destinationRunning {
+			err := client.StartConnectionHand(destination)
+			if nil != err {
+				return fmt.Errorf("Failed to start destination Processor: %s, %s", destination.Id, err)
+			}
+		}
+		err := client.DrainConnection(connection, timeout)
+		if nil != err {
+			if !destinationRunning {
+				client.StopConnectionHand(destination)
+			}
+			return fmt.Errorf("Failed to drain Connection: %s, %s", connectionId, err)
+		}
+	default:
+		err := client.DropConnectionData(connection)
 		if nil != err {
-			log.Printf("[INFO] Failed to start Processor: %s", connection.Component.Destination.Id)
+			return fmt.Errorf("Error purging Connection: %s", connectionId)
 		}
 	}
-
 	return nil
 }
 
+// Stop Helpers
+
+// ProcessorStop stops the processor and waits for its active threads, which are terminated on timeout
+// when terminate_on_stop_timeout is set.
+func ProcessorStop(client *nifi.Client, d *schema.ResourceData, processor *nifi.Processor) error {
+	timeout, err := time.ParseDuration(d.Get("stop_timeout").(string))
+	if err != nil {
+		timeout = nifi.ProcessorDefaultStopTimeout
+	}
+	return client.StopProcessorWithTimeout(processor, timeout, d.Get("terminate_on_stop_timeout").(bool))
+}
+
 // Schema Helpers
 
 func ProcessorFromSchema(d *schema.ResourceData, processor *nifi.Processor) error {
@@ -363,9 +541,9 @@ func ProcessorFromSchema(d *schema.ResourceData, processor *nifi.Processor) erro
 	}
 	config := v[0].(map[string]interface{})
 
-	processor.Component.Config.SchedulingStrategy = config["scheduling_strategy"].(nifi.SchedulingStrategy)
+	processor.Component.Config.SchedulingStrategy = nifi.SchedulingStrategy(config["scheduling_strategy"].(string))
 	processor.Component.Config.SchedulingPeriod = config["scheduling_period"].(string)
-	processor.Component.Config.ExecutionNode = config["execution_node"].(nifi.ExecutionNode)
+	processor.Component.Config.ExecutionNode = nifi.ExecutionNode(config["execution_node"].(string))
 	processor.Component.Config.ConcurrentlySchedulableTaskCount = config["concurrently_schedulable_task_count"].(int)
 
 	processor.Component.Config.Properties = map[string]interface{}{}
@@ -389,6 +567,7 @@ func ProcessorToSchema(d *schema.ResourceData, processor *nifi.Processor) error
 		"version": processor.Revision.Version,
 	}}
 	d.Set("revision", revision)
+	ComponentValidationToSchema(d, processor.Component.ValidationStatus, processor.Component.ValidationErrors)
 
 	relationships := []interface{}{}
 	for _, v := range processor.Component.Config.AutoTerminatedRelationships {
@@ -405,9 +584,9 @@ func ProcessorToSchema(d *schema.ResourceData, processor *nifi.Processor) error
 		}},
 		"config": []map[string]interface{}{{
 			"concurrently_schedulable_task_count": processor.Component.Config.ConcurrentlySchedulableTaskCount,
-			"scheduling_strategy":                 processor.Component.Config.SchedulingStrategy,
+			"scheduling_strategy":                 string(processor.Component.Config.SchedulingStrategy),
 			"scheduling_period":                   processor.Component.Config.SchedulingPeriod,
-			"execution_node":                      processor.Component.Config.ExecutionNode,
+			"execution_node":                      string(processor.Component.Config.ExecutionNode),
 			"properties":                          processor.Component.Config.Properties,
 			"auto_terminated_relationships":       relationships,
 		}},
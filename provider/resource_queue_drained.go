@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	nifi "github.com/glympse/terraform-provider-nifi/nifi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// nifi_queue_drained has no counterpart in NiFi, creating it blocks until the connections are empty.
+// Changing the connections or the triggers waits again.
+
+func ResourceQueueDrained() *schema.Resource {
+	return &schema.Resource{
+		Create: ResourceQueueDrainedCreate,
+		Read:   ResourceQueueDrainedRead,
+		Delete: ResourceQueueDrainedDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"connection_ids": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MinItems: 1,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func ResourceQueueDrainedCreate(d *schema.ResourceData, meta interface{}) error {
+	connectionIds := []string{}
+	for _, v := range d.Get("connection_ids").([]interface{}) {
+		connectionIds = append(connectionIds, v.(string))
+	}
+
+	client := meta.(*nifi.Client)
+	err := client.WaitConnectionsEmpty(connectionIds, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return fmt.Errorf("Failed to wait for drained Connections: %s", err)
+	}
+
+	d.SetId(strconv.FormatInt(time.Now().UnixNano(), 10))
+	return nil
+}
+
+func ResourceQueueDrainedRead(d *schema.ResourceData, meta interface{}) error {
+	return nil
+}
+
+func ResourceQueueDrainedDelete(d *schema.ResourceData, meta interface{}) error {
+	d.SetId("")
+	return nil
+}
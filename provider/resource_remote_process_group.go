@@ -3,6 +3,8 @@ package provider
 import (
 	"fmt"
 	"log"
+	"strings"
+	"time"
 
 	nifi "github.com/glympse/terraform-provider-nifi/nifi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -16,9 +18,24 @@ func ResourceRemoteProcessGroup() *schema.Resource {
 		Delete: ResourceRemoteProcessGroupDelete,
 		Exists: ResourceRemoteProcessGroupExists,
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"parent_group_id": SchemaParentGroupId(),
 			"revision":        SchemaRevision(),
+			"input_port_ids": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"output_port_ids": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 			"component": {
 				Type:     schema.TypeList,
 				Required: true,
@@ -33,6 +50,10 @@ func ResourceRemoteProcessGroup() *schema.Resource {
 							Required: true,
 						},
 						"position": SchemaPosition(),
+						"comments": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
 						"target_uris": {
 							Type:     schema.TypeString,
 							Required: true,
@@ -40,8 +61,49 @@ func ResourceRemoteProcessGroup() *schema.Resource {
 						"transport_protocol": {
 							Type:     schema.TypeString,
 							Optional: true,
-							Default:  "http",
+							Default:  "HTTP",
+							DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+								return strings.EqualFold(old, new)
+							},
+						},
+						"communications_timeout": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "30 sec",
+						},
+						"yield_duration": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "10 sec",
+						},
+						"local_network_interface": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"proxy_host": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"proxy_port": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"proxy_user": {
+							Type:     schema.TypeString,
+							Optional: true,
 						},
+						"proxy_password": {
+							Type:      schema.TypeString,
+							Optional:  true,
+							Sensitive: true,
+						},
+						"transmitting": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"input_port":  SchemaRemoteProcessGroupPort(),
+						"output_port": SchemaRemoteProcessGroupPort(),
 					},
 				},
 			},
@@ -49,6 +111,43 @@ func ResourceRemoteProcessGroup() *schema.Resource {
 	}
 }
 
+func SchemaRemoteProcessGroupPort() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"concurrently_schedulable_task_count": {
+					Type:     schema.TypeInt,
+					Optional: true,
+					Default:  1,
+				},
+				"use_compression": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+				"batch_count": {
+					Type:     schema.TypeInt,
+					Optional: true,
+				},
+				"batch_size": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"batch_duration": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+			},
+		},
+	}
+}
+
 func ResourceRemoteProcessGroupCreate(d *schema.ResourceData, meta interface{}) error {
 	processGroup := nifi.RemoteProcessGroup{}
 	processGroup.Revision.Version = 0
@@ -62,12 +161,17 @@ func ResourceRemoteProcessGroupCreate(d *schema.ResourceData, meta interface{})
 	client := meta.(*nifi.Client)
 	err = client.CreateRemoteProcessGroup(&processGroup)
 	if err != nil {
-		return fmt.Errorf("Failed to create Remote Process Group")
+		return fmt.Errorf("Failed to create Remote Process Group: %s", err)
 	}
 
 	d.SetId(processGroup.Component.Id)
 	d.Set("parent_group_id", parentGroupId)
 
+	err = RemoteProcessGroupConfigure(client, d, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return err
+	}
+
 	return ResourceRemoteProcessGroupRead(d, meta)
 }
 
@@ -102,6 +206,14 @@ func ResourceRemoteProcessGroupUpdate(d *schema.ResourceData, meta interface{})
 		}
 	}
 
+	// Remote process group cannot be modified while transmitting
+	if processGroup.Component.Transmitting != nil && *processGroup.Component.Transmitting {
+		err = client.StopRemoteProcessGroupTransmission(processGroup)
+		if err != nil {
+			return fmt.Errorf("Failed to stop Remote Process Group transmission: %s", processGroupId)
+		}
+	}
+
 	err = RemoteProcessGroupFromSchema(d, processGroup)
 	if err != nil {
 		return fmt.Errorf("Failed to parse Remote Process Group schema: %s", processGroupId)
@@ -112,6 +224,11 @@ func ResourceRemoteProcessGroupUpdate(d *schema.ResourceData, meta interface{})
 		return fmt.Errorf("Failed to update Remote Process Group: %s", processGroupId)
 	}
 
+	err = RemoteProcessGroupConfigure(client, d, d.Timeout(schema.TimeoutUpdate))
+	if err != nil {
+		return err
+	}
+
 	return ResourceRemoteProcessGroupRead(d, meta)
 }
 
@@ -130,6 +247,13 @@ func ResourceRemoteProcessGroupDelete(d *schema.ResourceData, meta interface{})
 		}
 	}
 
+	if processGroup.Component.Transmitting != nil && *processGroup.Component.Transmitting {
+		err = client.StopRemoteProcessGroupTransmission(processGroup)
+		if err != nil {
+			return fmt.Errorf("Failed to stop Remote Process Group transmission: %s", processGroupId)
+		}
+	}
+
 	err = client.DeleteRemoteProcessGroup(processGroup)
 	if err != nil {
 		return fmt.Errorf("Error deleting Remote Process Group: %s", processGroupId)
@@ -157,6 +281,107 @@ func ResourceRemoteProcessGroupExists(d *schema.ResourceData, meta interface{})
 	return true, nil
 }
 
+// Remote Port Helpers
+
+// RemoteProcessGroupConfigure waits for the configured remote ports to be discovered, applies their settings and
+// then brings the transmission to the desired state.
+func RemoteProcessGroupConfigure(client *nifi.Client, d *schema.ResourceData, timeout time.Duration) error {
+	processGroupId := d.Id()
+	component := d.Get("component.0").(map[string]interface{})
+	inputPorts := RemoteProcessGroupPortsFromSchema(component["input_port"].([]interface{}))
+	outputPorts := RemoteProcessGroupPortsFromSchema(component["output_port"].([]interface{}))
+
+	inputPortNames := []string{}
+	for _, port := range inputPorts {
+		inputPortNames = append(inputPortNames, port.Name)
+	}
+	outputPortNames := []string{}
+	for _, port := range outputPorts {
+		outputPortNames = append(outputPortNames, port.Name)
+	}
+
+	processGroup, err := client.WaitRemoteProcessGroupPorts(processGroupId, inputPortNames, outputPortNames, timeout)
+	if err != nil {
+		return err
+	}
+
+	for _, port := range inputPorts {
+		err = RemoteProcessGroupConfigurePort(client, processGroup, port, nifi.PortType_INPUT_PORT)
+		if err != nil {
+			return err
+		}
+	}
+	for _, port := range outputPorts {
+		err = RemoteProcessGroupConfigurePort(client, processGroup, port, nifi.PortType_OUTPUT_PORT)
+		if err != nil {
+			return err
+		}
+	}
+
+	if component["transmitting"].(bool) {
+		err = client.StartRemoteProcessGroupTransmission(processGroup)
+		if err != nil {
+			return fmt.Errorf("Failed to start Remote Process Group transmission: %s", processGroupId)
+		}
+	}
+	return nil
+}
+
+func RemoteProcessGroupConfigurePort(client *nifi.Client, processGroup *nifi.RemoteProcessGroup, desired nifi.RemoteProcessGroupPortComponent, portType nifi.PortType) error {
+	port := processGroup.FindPort(desired.Name, portType)
+	if port == nil {
+		return fmt.Errorf("Remote port %s was not found in Remote Process Group: %s", desired.Name, processGroup.Component.Id)
+	}
+	desired.Id = port.Id
+	err := client.UpdateRemoteProcessGroupPort(processGroup, &desired, portType)
+	if err != nil {
+		return fmt.Errorf("Failed to configure remote port %s: %s", desired.Name, err)
+	}
+	return nil
+}
+
+func RemoteProcessGroupPortsFromSchema(v []interface{}) []nifi.RemoteProcessGroupPortComponent {
+	ports := []nifi.RemoteProcessGroupPortComponent{}
+	for _, vv := range v {
+		port := vv.(map[string]interface{})
+		ports = append(ports, nifi.RemoteProcessGroupPortComponent{
+			Name:                             port["name"].(string),
+			ConcurrentlySchedulableTaskCount: port["concurrently_schedulable_task_count"].(int),
+			UseCompression:                   port["use_compression"].(bool),
+			BatchSettings: &nifi.RemoteProcessGroupPortBatchSettings{
+				Count:    port["batch_count"].(int),
+				Size:     port["batch_size"].(string),
+				Duration: port["batch_duration"].(string),
+			},
+		})
+	}
+	return ports
+}
+
+func RemoteProcessGroupPortsToSchema(v []interface{}, processGroup *nifi.RemoteProcessGroup, portType nifi.PortType) []interface{} {
+	ports := []interface{}{}
+	for _, vv := range v {
+		configured := vv.(map[string]interface{})
+		port := processGroup.FindPort(configured["name"].(string), portType)
+		if port == nil {
+			continue
+		}
+		batchSettings := nifi.RemoteProcessGroupPortBatchSettings{}
+		if port.BatchSettings != nil {
+			batchSettings = *port.BatchSettings
+		}
+		ports = append(ports, map[string]interface{}{
+			"name":                                port.Name,
+			"concurrently_schedulable_task_count": port.ConcurrentlySchedulableTaskCount,
+			"use_compression":                     port.UseCompression,
+			"batch_count":                         batchSettings.Count,
+			"batch_size":                          batchSettings.Size,
+			"batch_duration":                      batchSettings.Duration,
+		})
+	}
+	return ports
+}
+
 // Schema Helpers
 
 func RemoteProcessGroupFromSchema(d *schema.ResourceData, processGroup *nifi.RemoteProcessGroup) error {
@@ -169,6 +394,7 @@ func RemoteProcessGroupFromSchema(d *schema.ResourceData, processGroup *nifi.Rem
 	parentGroupId := component["parent_group_id"].(string)
 	processGroup.Component.ParentGroupId = parentGroupId
 	processGroup.Component.Name = component["name"].(string)
+	processGroup.Component.Comments = component["comments"].(string)
 
 	v = component["position"].([]interface{})
 	if len(v) != 1 {
@@ -178,8 +404,24 @@ func RemoteProcessGroupFromSchema(d *schema.ResourceData, processGroup *nifi.Rem
 	processGroup.Component.Position.X = position["x"].(float64)
 	processGroup.Component.Position.Y = position["y"].(float64)
 
-	processGroup.Component.TargetUris = component["targetUris"].(string)
-	processGroup.Component.TransportProtocol = component["transportProtocol"].(string)
+	processGroup.Component.TargetUris = component["target_uris"].(string)
+	processGroup.Component.TransportProtocol = strings.ToUpper(component["transport_protocol"].(string))
+	processGroup.Component.CommunicationsTimeout = component["communications_timeout"].(string)
+	processGroup.Component.YieldDuration = component["yield_duration"].(string)
+	processGroup.Component.LocalNetworkInterface = component["local_network_interface"].(string)
+	processGroup.Component.ProxyHost = component["proxy_host"].(string)
+	processGroup.Component.ProxyPort = nil
+	if proxyPort := component["proxy_port"].(int); proxyPort > 0 {
+		processGroup.Component.ProxyPort = &proxyPort
+	}
+	processGroup.Component.ProxyUser = component["proxy_user"].(string)
+	processGroup.Component.ProxyPassword = component["proxy_password"].(string)
+
+	// Transmission and remote ports are managed through their own endpoints
+	processGroup.Component.Transmitting = nil
+	processGroup.Component.Contents = nil
+	processGroup.Component.FlowRefreshed = ""
+	processGroup.Component.AuthorizationIssues = nil
 
 	return nil
 }
@@ -190,15 +432,49 @@ func RemoteProcessGroupToSchema(d *schema.ResourceData, processGroup *nifi.Remot
 	}}
 	d.Set("revision", revision)
 
+	inputPortIds := map[string]interface{}{}
+	outputPortIds := map[string]interface{}{}
+	if processGroup.Component.Contents != nil {
+		for _, port := range processGroup.Component.Contents.InputPorts {
+			inputPortIds[port.Name] = port.Id
+		}
+		for _, port := range processGroup.Component.Contents.OutputPorts {
+			outputPortIds[port.Name] = port.Id
+		}
+	}
+	d.Set("input_port_ids", inputPortIds)
+	d.Set("output_port_ids", outputPortIds)
+
+	proxyPort := 0
+	if processGroup.Component.ProxyPort != nil {
+		proxyPort = *processGroup.Component.ProxyPort
+	}
+	transmitting := false
+	if processGroup.Component.Transmitting != nil {
+		transmitting = *processGroup.Component.Transmitting
+	}
+
 	component := []map[string]interface{}{{
 		"parent_group_id": d.Get("parent_group_id").(string),
 		"name":            processGroup.Component.Name,
+		"comments":        processGroup.Component.Comments,
 		"position": []map[string]interface{}{{
 			"x": processGroup.Component.Position.X,
 			"y": processGroup.Component.Position.Y,
 		}},
-		"targetUris":        processGroup.Component.TargetUris,
-		"transportProtocol": processGroup.Component.TransportProtocol,
+		"target_uris":             processGroup.Component.TargetUris,
+		"transport_protocol":      processGroup.Component.TransportProtocol,
+		"communications_timeout":  processGroup.Component.CommunicationsTimeout,
+		"yield_duration":          processGroup.Component.YieldDuration,
+		"local_network_interface": processGroup.Component.LocalNetworkInterface,
+		"proxy_host":              processGroup.Component.ProxyHost,
+		"proxy_port":              proxyPort,
+		"proxy_user":              processGroup.Component.ProxyUser,
+		// NiFi masks the password, keep the configured one
+		"proxy_password": d.Get("component.0.proxy_password").(string),
+		"transmitting":   transmitting,
+		"input_port":     RemoteProcessGroupPortsToSchema(d.Get("component.0.input_port").([]interface{}), processGroup, nifi.PortType_INPUT_PORT),
+		"output_port":    RemoteProcessGroupPortsToSchema(d.Get("component.0.output_port").([]interface{}), processGroup, nifi.PortType_OUTPUT_PORT),
 	}}
 	d.Set("component", component)
 
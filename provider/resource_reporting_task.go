@@ -6,19 +6,28 @@ import (
 
 	nifi "github.com/glympse/terraform-provider-nifi/nifi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func ResourceReportingTask() *schema.Resource {
 	return &schema.Resource{
-		Create: ResourceReportingTaskCreate,
-		Read:   ResourceReportingTaskRead,
-		Update: ResourceReportingTaskUpdate,
-		Delete: ResourceReportingTaskDelete,
-		Exists: ResourceReportingTaskExists,
+		CreateContext: ComponentValidationApply(ResourceReportingTaskCreate, ResourceReportingTaskRead),
+		Read:          ResourceReportingTaskRead,
+		UpdateContext: ComponentValidationApply(ResourceReportingTaskUpdate, ResourceReportingTaskRead),
+		Delete:        ResourceReportingTaskDelete,
+		Exists:        ResourceReportingTaskExists,
+		CustomizeDiff: ComponentValidationCustomizeDiff(nifi.ComponentKind_REPORTING_TASK, "component.0.properties", ""),
+		Importer: &schema.ResourceImporter{
+			State: ResourceReportingTaskImport,
+		},
 
 		Schema: map[string]*schema.Schema{
-			"parent_group_id": SchemaParentGroupId(),
-			"revision":        SchemaRevision(),
+			"parent_group_id":          SchemaParentGroupId(),
+			"revision":                 SchemaRevision(),
+			"validation_status":        SchemaValidationStatus(),
+			"validation_errors":        SchemaValidationErrors(),
+			"validation_severity":      SchemaValidationSeverity(),
+			"allow_dynamic_properties": SchemaAllowDynamicProperties(),
 			"component": {
 				Type:     schema.TypeList,
 				Required: true,
@@ -54,6 +63,16 @@ func ResourceReportingTask() *schema.Resource {
 							Type:     schema.TypeMap,
 							Required: true,
 						},
+						"state": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  string(nifi.ReportingTaskState_RUNNING),
+							ValidateFunc: validation.StringInSlice([]string{
+								string(nifi.ReportingTaskState_RUNNING),
+								string(nifi.ReportingTaskState_STOPPED),
+								string(nifi.ReportingTaskState_DISABLED),
+							}, false),
+						},
 					},
 				},
 			},
@@ -80,6 +99,15 @@ func ResourceReportingTaskCreate(d *schema.ResourceData, meta interface{}) error
 	d.SetId(reportingTask.Component.Id)
 	d.Set("parent_group_id", parentGroupId)
 
+	// Move reporting task to its desired state, tasks are created stopped
+	state := nifi.ReportingTaskState(d.Get("component.0.state").(string))
+	err = client.SetReportingTaskState(&reportingTask, state)
+	if err != nil {
+		// Record the actual state so that the next plan retries the transition
+		ResourceReportingTaskRead(d, meta)
+		return fmt.Errorf("Failed to set Reporting Task %s state to %s: %s", reportingTask.Component.Id, state, err)
+	}
+
 	return ResourceReportingTaskRead(d, meta)
 }
 
@@ -114,6 +142,14 @@ func ResourceReportingTaskUpdate(d *schema.ResourceData, meta interface{}) error
 		}
 	}
 
+	// Stop reporting task if it is currently running
+	if nifi.ReportingTaskState_RUNNING == reportingTask.Component.State {
+		err = client.StopReportingTask(reportingTask)
+		if err != nil {
+			return fmt.Errorf("Failed to stop Reporting Task: %s, %s", reportingTaskId, err)
+		}
+	}
+
 	err = ReportingTaskFromSchema(d, reportingTask)
 	if err != nil {
 		return fmt.Errorf("Failed to parse Reporting Task schema: %s", reportingTaskId)
@@ -124,6 +160,15 @@ func ResourceReportingTaskUpdate(d *schema.ResourceData, meta interface{}) error
 		return fmt.Errorf("Failed to update Reporting Task: %s", reportingTaskId)
 	}
 
+	// Move reporting task to its desired state again
+	state := nifi.ReportingTaskState(d.Get("component.0.state").(string))
+	err = client.SetReportingTaskState(reportingTask, state)
+	if err != nil {
+		// Record the actual state so that the next plan retries the transition
+		ResourceReportingTaskRead(d, meta)
+		return fmt.Errorf("Failed to set Reporting Task %s state to %s: %s", reportingTaskId, state, err)
+	}
+
 	return ResourceReportingTaskRead(d, meta)
 }
 
@@ -142,6 +187,14 @@ func ResourceReportingTaskDelete(d *schema.ResourceData, meta interface{}) error
 		}
 	}
 
+	// Stop reporting task if it is currently running
+	if nifi.ReportingTaskState_RUNNING == reportingTask.Component.State {
+		err = client.StopReportingTask(reportingTask)
+		if err != nil {
+			return fmt.Errorf("Failed to stop Reporting Task: %s, %s", reportingTaskId, err)
+		}
+	}
+
 	err = client.DeleteReportingTask(reportingTask)
 	if err != nil {
 		return fmt.Errorf("Error deleting Reporting Task: %s", reportingTaskId)
@@ -169,6 +222,19 @@ func ResourceReportingTaskExists(d *schema.ResourceData, meta interface{}) (bool
 	return true, nil
 }
 
+func ResourceReportingTaskImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	reportingTaskId := d.Id()
+
+	client := meta.(*nifi.Client)
+	reportingTask, err := client.GetReportingTask(reportingTaskId)
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving Reporting Task: %s, %s", reportingTaskId, err)
+	}
+	d.Set("parent_group_id", reportingTask.Component.ParentGroupId)
+
+	return []*schema.ResourceData{d}, nil
+}
+
 // Schema Helpers
 
 func ReportingTaskFromSchema(d *schema.ResourceData, reportingTask *nifi.ReportingTask) error {
@@ -182,6 +248,7 @@ func ReportingTaskFromSchema(d *schema.ResourceData, reportingTask *nifi.Reporti
 	reportingTask.Component.ParentGroupId = parentGroupId
 	reportingTask.Component.Name = component["name"].(string)
 	reportingTask.Component.Type = component["type"].(string)
+	reportingTask.Component.Comments = component["comments"].(string)
 
 	reportingTask.Component.Properties = map[string]interface{}{}
 	properties := component["properties"].(map[string]interface{})
@@ -200,12 +267,15 @@ func ReportingTaskToSchema(d *schema.ResourceData, reportingTask *nifi.Reporting
 		"version": reportingTask.Revision.Version,
 	}}
 	d.Set("revision", revision)
+	ComponentValidationToSchema(d, reportingTask.Component.ValidationStatus, reportingTask.Component.ValidationErrors)
 
 	component := []map[string]interface{}{{
 		"parent_group_id":     d.Get("parent_group_id").(string),
 		"name":                reportingTask.Component.Name,
 		"type":                reportingTask.Component.Type,
+		"comments":            reportingTask.Component.Comments,
 		"properties":          reportingTask.Component.Properties,
+		"state":               string(reportingTask.Component.State),
 		"scheduling_strategy": reportingTask.Component.SchedulingStrategy,
 		"scheduling_period":   reportingTask.Component.SchedulingPeriod,
 	}}
@@ -93,11 +93,11 @@ func ResourceUserUpdate(d *schema.ResourceData, meta interface{}) error {
 
 func ResourceUserDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*nifi.Client)
-	client.Lock.Lock()
+	unlock := client.LockKeys(TenantsLockKey)
 	log.Printf("[INFO] Deleting User: %s...", d.Id())
 	err := ResourceUserDeleteInternal(d, meta)
 	log.Printf("[INFO] User deleted: %s", d.Id())
-	defer client.Lock.Unlock()
+	defer unlock()
 	return err
 }
 
@@ -42,3 +42,21 @@ func SchemaPosition() *schema.Schema {
 		},
 	}
 }
+
+// ComponentSettingConfigured tells whether a setting of the component block is set in the configuration,
+// including to an empty or zero value.
+func ComponentSettingConfigured(d *schema.ResourceData, key string) bool {
+	config := d.GetRawConfig()
+	if !config.IsKnown() || config.IsNull() {
+		return false
+	}
+	components := config.GetAttr("component")
+	if !components.IsKnown() || components.IsNull() || components.LengthInt() != 1 {
+		return false
+	}
+	component := components.AsValueSlice()[0]
+	if !component.IsKnown() || component.IsNull() {
+		return false
+	}
+	return !component.GetAttr(key).IsNull()
+}